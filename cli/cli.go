@@ -5,9 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"runtime"
+	"strings"
+	"text/template"
 
-	mango "github.com/muesli/mango-cobra"
-	"github.com/muesli/roff"
 	"github.com/spf13/cobra"
 )
 
@@ -15,25 +16,67 @@ import (
 type Option func(*options)
 
 type options struct {
-	ctx            context.Context
-	completion     *completionOptions
-	manpages       bool
-	stdout         io.Writer
-	stderr         io.Writer
-	theme          Theme
-	version        *VersionInfo
-	versionCommand bool
-	width          int
+	args                []string
+	autoEnv             *string
+	banner              string
+	ctx                 context.Context
+	collapseGlobalFlags bool
+	commandSorting      *bool
+	completion          *completionOptions
+	completionCommand   bool
+	configFile          string
+	configFormat        string
+	confirmFlag         bool
+	docs                *docsOptions
+	dotenv              []string
+	dotenvRequired      bool
+	envOverrides        bool
+	envSection          bool
+	errorFormat         ErrorFormat
+	exitCodeMapper      func(error) int
+	helpAll             bool
+	helpEnumLimit       int
+	helpFooter          string
+	helpSections        []Section
+	hiddenEnvDocs       bool
+	logger              bool
+	manpages            bool
+	preRun              func(*cobra.Command, []string) error
+	recover             bool
+	showHidden          bool
+	signals             []os.Signal
+	stdin               io.Reader
+	silenceErrors       *bool
+	silenceUsage        *bool
+	stdout              io.Writer
+	stderr              io.Writer
+	theme               Theme
+	updateCheck         *UpdateConfig
+	verbosity           bool
+	version             *VersionInfo
+	versionCommand      bool
+	versionTmpl         string
+	width               *int
 }
 
 func defaultOptions() *options {
 	return &options{
-		ctx:      context.Background(),
-		manpages: true,
-		stdout:   os.Stdout,
-		stderr:   os.Stderr,
-		theme:    DefaultTheme(),
-		width:    80,
+		ctx:           context.Background(),
+		helpEnumLimit: DefaultEnumLimit,
+		manpages:      true,
+		stdin:         os.Stdin,
+		stdout:        os.Stdout,
+		stderr:        os.Stderr,
+		theme:         DefaultTheme(),
+	}
+}
+
+// WithStdin sets the standard input reader for the CLI.
+//
+//	cli.Execute(root, cli.WithStdin(strings.NewReader("hello")))
+func WithStdin(r io.Reader) Option {
+	return func(o *options) {
+		o.stdin = r
 	}
 }
 
@@ -72,7 +115,22 @@ func WithContext(ctx context.Context) Option {
 	}
 }
 
-// WithTheme sets the theme for styling the CLI help output.
+// WithArgs sets the arguments passed to the command, in place of those
+// provided on the command line. This is useful when driving a CLI from tests.
+//
+//	cli.Execute(root, cli.WithArgs("tag", "--message", "release"))
+func WithArgs(args ...string) Option {
+	return func(o *options) {
+		// Never nil, so an empty set of arguments is still applied
+		o.args = append([]string{}, args...)
+	}
+}
+
+// WithTheme sets the theme for styling the CLI help output. The theme is only
+// applied when stdout is a terminal, or when the FORCE_COLOR environment
+// variable is set. If the NO_COLOR environment variable is set, the theme is
+// always ignored and [DefaultTheme] is used. Any styles left unset within the
+// theme fall back to those of [DefaultTheme].
 //
 //	theme := cli.DefaultTheme()
 //	theme.Header = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("141"))
@@ -80,24 +138,239 @@ func WithContext(ctx context.Context) Option {
 //	cli.Execute(root, cli.WithTheme(theme))
 func WithTheme(t Theme) Option {
 	return func(o *options) {
-		o.theme = t
+		o.theme = t.withDefaults()
+	}
+}
+
+// WithHelpWidth sets the maximum width for word wrapping CLI help output,
+// overriding the width detected from the terminal. Text will wrap at word
+// boundaries to fit within the specified width. Set to 0 to disable wrapping.
+//
+// By default, the width of the terminal is used when stdout is attached to
+// one, falling back to the COLUMNS environment variable and then 80
+// characters. An explicit width keeps output stable when piped or under test.
+//
+//	cli.Execute(root, cli.WithHelpWidth(100))
+func WithHelpWidth(w int) Option {
+	return func(o *options) {
+		o.width = &w
 	}
 }
 
 // WithWidth sets the maximum width for word wrapping CLI help output.
-// Text will wrap at word boundaries to fit within the specified width.
-// The default width is 80 characters. Set to 0 to disable wrapping.
 //
-//	cli.Execute(root, cli.WithWidth(100))
+// Deprecated: use [WithHelpWidth], which behaves identically.
 func WithWidth(w int) Option {
+	return WithHelpWidth(w)
+}
+
+// DefaultEnumLimit is the maximum number of allowed values listed within the
+// placeholder of an enum flag, unless changed through [WithEnumLimit].
+const DefaultEnumLimit = 6
+
+// WithEnumLimit sets the maximum number of allowed values listed within the
+// placeholder of an enum flag (e.g., <debug|info|warn|error>), in help,
+// manpages and Markdown docs alike. Any further values are truncated with an
+// ellipsis. The default limit is
+// [DefaultEnumLimit]. Set to 0 to always list every value.
+//
+//	cli.Execute(root, cli.WithEnumLimit(3))
+func WithEnumLimit(n int) Option {
+	return func(o *options) {
+		o.helpEnumLimit = n
+	}
+}
+
+// WithAutoEnv binds every flag across the command tree to an environment
+// variable derived from its name and prefix, as [BindEnvPrefix] does, without
+// a separate call after the flags are defined. Flags bound through [BindEnv]
+// keep their explicit environment variable. Flags and commands added by
+// [Execute] itself, such as --version or --yes, are never bound.
+//
+//	cli.Execute(root, cli.WithAutoEnv("MYAPP"))
+//	// --log-level is bound to MYAPP_LOG_LEVEL
+func WithAutoEnv(prefix string) Option {
+	return func(o *options) {
+		o.autoEnv = &prefix
+	}
+}
+
+// WithEnvOverridesFlags gives environment variables bound through [BindEnv]
+// precedence over flags explicitly provided on the command line. This suits
+// deployments that must enforce values, such as secrets, regardless of how
+// the CLI is invoked.
+//
+// Use with care: a stale or unexpected environment variable silently replaces
+// what the user typed, which can be surprising and hard to diagnose.
+//
+//	cli.Execute(root, cli.WithEnvOverridesFlags())
+func WithEnvOverridesFlags() Option {
+	return func(o *options) {
+		o.envOverrides = true
+	}
+}
+
+// WithPreRun runs fn before any other processing of the executed command,
+// ahead of environment variables being applied to flags and flag requirements
+// being validated. This suits work such as loading a config file that
+// populates defaults. Any persistent pre-run declared on a command still runs
+// afterwards.
+//
+//	cli.Execute(root, cli.WithPreRun(func(cmd *cobra.Command, args []string) error {
+//		return loadConfig(cmd)
+//	}))
+func WithPreRun(fn func(*cobra.Command, []string) error) Option {
+	return func(o *options) {
+		o.preRun = fn
+	}
+}
+
+// WithSilenceUsage controls whether usage is printed when a command fails. By
+// default, usage is only printed for misuse, such as invalid arguments or
+// flags, and not for errors returned while running the command. Passing true
+// never prints usage, while false always prints it.
+//
+//	cli.Execute(root, cli.WithSilenceUsage(true))
+func WithSilenceUsage(silence bool) Option {
+	return func(o *options) {
+		o.silenceUsage = &silence
+	}
+}
+
+// WithSilenceErrors controls whether errors returned from a command are
+// printed to stderr. Errors are printed by default. Silence them when the
+// caller reports errors itself.
+//
+//	if err := cli.Execute(root, cli.WithSilenceErrors(true)); err != nil {
+//	    log.Fatal(err)
+//	}
+func WithSilenceErrors(silence bool) Option {
+	return func(o *options) {
+		o.silenceErrors = &silence
+	}
+}
+
+// WithCollapsedGlobalFlags replaces the GLOBAL FLAGS section of every
+// subcommand with a one-line hint pointing to the root command's help, keeping
+// the help of deeply nested commands focused on their own flags.
+//
+//	cli.Execute(root, cli.WithCollapsedGlobalFlags())
+//
+// To collapse global flags for individual commands only, annotate them:
+//
+//	cmd.Annotations = map[string]string{"collapseInheritedFlags": "true"}
+func WithCollapsedGlobalFlags() Option {
+	return func(o *options) {
+		o.collapseGlobalFlags = true
+	}
+}
+
+// WithCommandSorting controls whether subcommands are sorted alphabetically
+// in help output. Cobra sorts commands by default. Pass false to present them
+// in the order they were added, allowing a curated order.
+//
+//	cli.Execute(root, cli.WithCommandSorting(false))
+func WithCommandSorting(sorted bool) Option {
+	return func(o *options) {
+		o.commandSorting = &sorted
+	}
+}
+
+// WithBanner prints text above the help of the root command, such as the
+// name of the CLI in ASCII art. The text is dedented and styled as a command,
+// with any line wider than the configured width truncated. Subcommand help
+// does not include the banner.
+//
+//	cli.Execute(root, cli.WithBanner(`
+//	    ┏┓┏┏┓┓┏
+//	    ┛┗┛┛ ┗┛
+//	`))
+func WithBanner(text string) Option {
+	return func(o *options) {
+		o.banner = text
+	}
+}
+
+// WithHelpAll adds a persistent --help-all flag to the root command, which
+// prints the help of a command and every command beneath it in a single
+// output. This suits piping the help of an entire CLI to a pager.
+//
+//	cli.Execute(root, cli.WithHelpAll())
+func WithHelpAll() Option {
+	return func(o *options) {
+		o.helpAll = true
+	}
+}
+
+// WithHelpFooter prints text at the bottom of every command's help, such as a
+// link to further documentation. The text is dedented and wrapped to the
+// configured width.
+//
+//	cli.Execute(root, cli.WithHelpFooter("Learn more at https://github.com/purpleclay/nsv"))
+func WithHelpFooter(text string) Option {
+	return func(o *options) {
+		o.helpFooter = text
+	}
+}
+
+// WithHelpSections changes the order in which sections are rendered within
+// the help output. Any section left out is not rendered, while sections without
+// content are always skipped. The default order is description, usage,
+// commands, examples, flags and then global flags.
+//
+//	cli.Execute(root, cli.WithHelpSections(
+//	    cli.SectionDescription,
+//	    cli.SectionUsage,
+//	    cli.SectionFlags,
+//	    cli.SectionCommands,
+//	    cli.SectionExamples,
+//	    cli.SectionGlobalFlags,
+//	))
+func WithHelpSections(order ...Section) Option {
+	return func(o *options) {
+		o.helpSections = order
+	}
+}
+
+// WithEnvSection lists every flag bound to an environment variable within an
+// ENVIRONMENT section of the help output, alongside the flag it maps to. The
+// section is omitted when a command has no bound flags.
+//
+//	cli.Execute(root, cli.WithEnvSection())
+func WithEnvSection() Option {
+	return func(o *options) {
+		o.envSection = true
+	}
+}
+
+// WithHiddenEnvDocs lists hidden flags bound to an environment variable within
+// an ENVIRONMENT section of the help output. Although hidden flags are omitted
+// from the help output, their environment variables are still applied, so
+// listing them keeps them discoverable.
+//
+//	cli.Execute(root, cli.WithHiddenEnvDocs())
+func WithHiddenEnvDocs() Option {
 	return func(o *options) {
-		o.width = w
+		o.hiddenEnvDocs = true
+	}
+}
+
+// WithShowHidden includes hidden commands and flags within the help output,
+// which is useful when debugging a CLI. Hidden entries are omitted by default.
+//
+//	cli.Execute(root, cli.WithShowHidden())
+func WithShowHidden() Option {
+	return func(o *options) {
+		o.showHidden = true
 	}
 }
 
 // WithoutManpage disables the hidden "man" command that generates a manpage.
 // By default, a hidden "man" command is available that outputs a roff-formatted
-// manpage which can be installed by piping to a file in your manpath.
+// manpage which can be installed by piping to a file in your manpath. Passing
+// --output-dir writes a manpage for every command to a directory instead.
+// Manpages share the flag descriptions, environment variables and enum values
+// of the help output.
 func WithoutManpage() Option {
 	return func(o *options) {
 		o.manpages = false
@@ -149,9 +422,29 @@ func WithVersionCommand(info VersionInfo) Option {
 	}
 }
 
+// WithVersionTemplate renders build information through a custom [text/template]
+// instead of the default BUILD INFORMATION block. The template receives the
+// [VersionInfo] as its data and is validated when [Execute] is called. The
+// --short and --json flags of the version command are unaffected.
+//
+//	cli.Execute(root,
+//	    cli.WithVersionCommand(info),
+//	    cli.WithVersionTemplate("{{.Version}} ({{.GitCommit}}) built {{.BuildDate}}\n"),
+//	)
+func WithVersionTemplate(tmpl string) Option {
+	return func(o *options) {
+		o.versionTmpl = tmpl
+	}
+}
+
 // WithCompletionCommand adds a "completion" subcommand that generates shell
 // completion scripts. By default, it supports bash, zsh, and fish shells.
 //
+// A script generated with --no-descriptions omits the descriptions of values
+// by setting an environment variable named after the root command, such as
+// NSV_COMPLETION_NO_DESCRIPTIONS, within the shell. Setting the variable
+// directly has the same effect.
+//
 // Basic usage:
 //
 //	cli.Execute(root, cli.WithCompletionCommand())
@@ -174,14 +467,62 @@ func WithVersionCommand(info VersionInfo) Option {
 //	)
 func WithCompletionCommand(opts ...CompletionOption) Option {
 	return func(o *options) {
-		o.completion = defaultCompletionOptions()
+		o.completionCommand = true
+		WithCompletions(opts...)(o)
+	}
+}
+
+// WithCompletions applies completions to the command tree without adding the
+// "completion" subcommand, which is useful when completion scripts are
+// generated and shipped separately. It accepts the same options as
+// [WithCompletionCommand] and can be combined with it.
+//
+//	cli.Execute(root,
+//	    cli.WithCompletions(
+//	        cli.CompleteFlag("config", cli.Files(".yaml", ".json")),
+//	    ),
+//	)
+func WithCompletions(opts ...CompletionOption) Option {
+	return func(o *options) {
+		if o.completion == nil {
+			o.completion = defaultCompletionOptions()
+		}
 		for _, opt := range opts {
 			opt(o.completion)
 		}
 	}
 }
 
-const flagGroupAnnotation = "purpleclay_cli_group"
+const (
+	flagGroupAnnotation = "purpleclay_cli_group"
+	argsUsageAnnotation = "purpleclay_cli_args"
+	relatedAnnotation   = "purpleclay_cli_related"
+)
+
+// MarkRelated lists related commands within a SEE ALSO section of the
+// command's help. Commands are referenced by their path from the root
+// command, and any that cannot be found are silently skipped.
+//
+//	cli.MarkRelated(cmd, "tag", "remote add")
+func MarkRelated(cmd *cobra.Command, names ...string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[relatedAnnotation] = strings.Join(names, ",")
+}
+
+// ArgsUsage sets the positional arguments shown after the command within the
+// USAGE section of its help, overriding those taken from everything after the
+// first word of the command's Use field.
+//
+//	cmd := &cobra.Command{Use: "deploy [--dry-run] ENV"}
+//	cli.ArgsUsage(cmd, "<ENV>")
+func ArgsUsage(cmd *cobra.Command, args string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[argsUsageAnnotation] = args
+}
 
 // FlagGroup assigns flags to a named group for organized help output.
 // Grouped flags are rendered under their group header instead of the
@@ -218,6 +559,10 @@ func FlagGroup(cmd *cobra.Command, group string, flags ...string) {
 //	    os.Exit(1)
 //	}
 //
+// Or let [ExecuteMain] exit the process with an appropriate code:
+//
+//	cli.ExecuteMain(root)
+//
 // Functional options allow customisation:
 //
 //	cli.Execute(root,
@@ -226,57 +571,191 @@ func FlagGroup(cmd *cobra.Command, group string, flags ...string) {
 //	    cli.WithStderr(os.Stderr),
 //	)
 func Execute(cmd *cobra.Command, opts ...Option) error {
+	return execute(cmd, resolveOptions(opts))
+}
+
+func resolveOptions(opts []Option) *options {
 	o := defaultOptions()
 	for _, opt := range opts {
 		opt(o)
 	}
+	return o
+}
+
+// execute runs cmd with options that have already been resolved, allowing
+// [ExecuteMain] to inspect them without applying every option twice.
+func execute(cmd *cobra.Command, o *options) error {
+	if o.commandSorting != nil {
+		// Restore cobra's global setting so it does not leak beyond this call
+		defer func(sorting bool) { cobra.EnableCommandSorting = sorting }(cobra.EnableCommandSorting)
+		cobra.EnableCommandSorting = *o.commandSorting
+	}
+
+	switch enabled, forced := colorMode(o.stdout); {
+	case !enabled:
+		o.theme = DefaultTheme()
+	case forced:
+		defer forceColor()()
+	}
 
+	cmd.SetIn(o.stdin)
 	cmd.SetOut(o.stdout)
 	cmd.SetErr(o.stderr)
-	cmd.SetHelpFunc(helpFunc(o.theme, o.width))
-	cmd.SetUsageFunc(usageFunc(o.theme, o.width))
+	if o.args != nil {
+		cmd.SetArgs(o.args)
+	}
+	width := helpWidth(o.stdout)
+	if o.width != nil {
+		width = *o.width
+	}
+	help := helpConfig{
+		theme:               o.theme,
+		width:               width,
+		showHidden:          o.showHidden,
+		collapseGlobalFlags: o.collapseGlobalFlags,
+		banner:              o.banner,
+		footer:              o.helpFooter,
+		sections:            o.helpSections,
+		hiddenEnvDocs:       o.hiddenEnvDocs,
+		envSection:          o.envSection,
+		enumLimit:           o.helpEnumLimit,
+	}
+	cmd.SetHelpFunc(helpFunc(help))
+	cmd.SetUsageFunc(usageFunc(help))
+	addSecretFlagErrors(cmd)
 	cmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	cmd.CompletionOptions.DisableDefaultCmd = true
 	cmd.TraverseChildren = true
 
+	// Bind flags before any are added by the CLI itself, such as --version,
+	// which must never be set from the environment
+	if o.autoEnv != nil {
+		BindEnvPrefix(cmd, *o.autoEnv)
+	}
+
 	if o.manpages {
-		cmd.AddCommand(&cobra.Command{
-			Use:                   "man",
-			Short:                 "Generate a manpage for the CLI",
-			SilenceUsage:          true,
-			DisableFlagsInUseLine: true,
-			Hidden:                true,
-			Args:                  cobra.NoArgs,
-			RunE: func(_ *cobra.Command, _ []string) error {
-				page, err := mango.NewManPage(1, cmd)
-				if err != nil {
-					return err
-				}
-				_, err = fmt.Fprint(o.stdout, page.Build(roff.NewDocument()))
-				return err
-			},
-		})
+		cmd.AddCommand(newManCommand(o.helpEnumLimit))
+	}
+
+	if o.docs != nil {
+		var frontMatter *template.Template
+		if o.docs.frontMatter != "" {
+			tmpl, err := template.New("frontmatter").Parse(o.docs.frontMatter)
+			if err != nil {
+				return fmt.Errorf("invalid docs front matter template: %w", err)
+			}
+			frontMatter = tmpl
+		}
+		cmd.AddCommand(newDocsCommand(frontMatter, o.helpEnumLimit))
+	}
+
+	var versionTmpl *template.Template
+	if o.versionTmpl != "" {
+		tmpl, err := template.New("version").Parse(o.versionTmpl)
+		if err != nil {
+			return fmt.Errorf("invalid version template: %w", err)
+		}
+		versionTmpl = tmpl
 	}
 
 	if o.version != nil {
+		if o.version.Platform == "" {
+			o.version.Platform = runtime.GOOS + "/" + runtime.GOARCH
+		}
+
 		if o.versionCommand {
-			cmd.AddCommand(newVersionCommand(o.version, o.theme))
+			cmd.AddCommand(newVersionCommand(o.version, o.theme, versionTmpl))
 		} else {
-			cmd.Version = renderVersion(o.version, o.theme)
+			version := renderVersion(o.version, o.theme)
+			if versionTmpl != nil {
+				var err error
+				if version, err = renderVersionTemplate(versionTmpl, o.version); err != nil {
+					return err
+				}
+			}
+			cmd.Version = version
 			cmd.SetVersionTemplate("{{.Version}}")
 			cmd.Flags().BoolP("version", "V", false, "print build time version information")
 		}
 	}
 
+	if o.verbosity {
+		addVerbosityFlags(cmd)
+	}
+	if o.confirmFlag {
+		addConfirmFlag(cmd)
+	}
+	if o.logger {
+		addLogFlags(cmd)
+	}
+
 	if o.completion != nil {
-		cmd.AddCommand(newCompletionCommand(o.completion, cmd.Name()))
-		applyCompletions(cmd, o.completion)
+		registerCompletions(cmd, o.completion, o.completionCommand)
+	}
+
+	if len(o.dotenv) > 0 {
+		if err := loadDotEnv(o.dotenv, o.dotenvRequired); err != nil {
+			return err
+		}
 	}
 
-	if err := applyEnvBindings(cmd); err != nil {
-		return err
+	var config map[string]any
+	if o.configFile != "" {
+		var err error
+		if config, err = loadConfigFile(o.configFile, o.configFormat); err != nil {
+			return err
+		}
 	}
 
-	addFlagRequirementsValidation(cmd)
-	return cmd.ExecuteContext(o.ctx)
+	if o.silenceUsage != nil {
+		cmd.SilenceUsage = *o.silenceUsage
+	} else if o.errorFormat == ErrorFormatJSON {
+		cmd.SilenceUsage = true
+	}
+	if o.silenceErrors != nil {
+		cmd.SilenceErrors = *o.silenceErrors
+	}
+	if o.logger {
+		addLogger(cmd, o.stderr)
+	}
+	addFlagRequirementsValidation(cmd, o.silenceUsage == nil)
+	if o.envOverrides {
+		addEnvOverrides(cmd)
+	}
+	if config != nil {
+		addConfigFile(cmd, o.configFile, config)
+	}
+	addEnvBindings(cmd)
+	if o.preRun != nil {
+		wrapPersistentPreRun(cmd, o.preRun)
+	}
+	if o.helpAll {
+		addHelpAllFlag(cmd)
+	}
+	if o.recover {
+		addRecover(cmd)
+	}
+	var update *updateCheck
+	if o.updateCheck != nil {
+		update = addUpdateCheck(cmd, *o.updateCheck)
+	}
+
+	ctx := withTheme(o.ctx, o.theme)
+	if len(o.signals) > 0 {
+		var stop context.CancelFunc
+		ctx, stop = notifyContext(ctx, o.signals...)
+		defer stop()
+	}
+
+	var err error
+	if o.errorFormat == ErrorFormatJSON {
+		err = executeJSONErrors(ctx, cmd)
+	} else {
+		err = cmd.ExecuteContext(ctx)
+	}
+
+	if err == nil && update != nil {
+		update.notify(cmd.ErrOrStderr(), o.theme)
+	}
+	return err
 }
@@ -2,9 +2,13 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -73,3 +77,208 @@ func TestExecuteWithArgsValidator(t *testing.T) {
 	err = Execute(cmd, WithStdout(&buf), WithStderr(&buf))
 	require.Error(t, err)
 }
+
+func TestExecuteWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var cancelled bool
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			select {
+			case <-cmd.Context().Done():
+				cancelled = true
+			default:
+			}
+			return nil
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Execute(cmd, WithStdout(&buf), WithContext(ctx))
+
+	require.NoError(t, err)
+	require.True(t, cancelled)
+}
+
+func newFailingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "myapp [NAME]",
+		Short: "Example app",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return errors.New("connection refused")
+		},
+	}
+}
+
+func TestExecuteSilencesUsageOnRunError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	root := newFailingCmd()
+	root.SetArgs([]string{})
+
+	err := Execute(root, WithStdout(&stdout), WithStderr(&stderr))
+	require.EqualError(t, err, "connection refused")
+
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "Error: connection refused\n", stderr.String())
+}
+
+func TestExecuteShowsUsageOnMisuse(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	root := newFailingCmd()
+	root.SetArgs([]string{"a", "b"})
+
+	err := Execute(root, WithStdout(&stdout), WithStderr(&stderr))
+	require.EqualError(t, err, "accepts at most 1 arg(s), received 2")
+
+	assert.Contains(t, stdout.String(), "USAGE")
+}
+
+func TestExecuteWithSilenceUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		silence  bool
+		args     []string
+		hasUsage bool
+	}{
+		{name: "TrueOnMisuse", silence: true, args: []string{"a", "b"}, hasUsage: false},
+		{name: "FalseOnRunError", silence: false, args: []string{}, hasUsage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			root := newFailingCmd()
+			root.SetArgs(tt.args)
+
+			err := Execute(root, WithStdout(&stdout), WithStderr(&stderr), WithSilenceUsage(tt.silence))
+			require.Error(t, err)
+
+			output := stdout.String() + stderr.String()
+			if tt.hasUsage {
+				assert.Contains(t, output, "USAGE")
+			} else {
+				assert.NotContains(t, output, "USAGE")
+			}
+		})
+	}
+}
+
+func TestExecuteWithSilenceErrors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	root := newFailingCmd()
+	root.SetArgs([]string{})
+
+	err := Execute(root, WithStdout(&stdout), WithStderr(&stderr), WithSilenceErrors(true))
+	require.EqualError(t, err, "connection refused")
+
+	assert.Empty(t, stdout.String())
+	assert.Empty(t, stderr.String())
+}
+
+func TestExecuteWithPreRun(t *testing.T) {
+	var buf bytes.Buffer
+	var calls []string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			calls = append(calls, "user")
+			return nil
+		},
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+
+	err := Execute(cmd, WithStdout(&buf), WithPreRun(func(_ *cobra.Command, _ []string) error {
+		calls = append(calls, "prerun")
+		return nil
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"prerun", "user"}, calls)
+}
+
+func TestExecuteWithPreRunBeforeEnvAndValidation(t *testing.T) {
+	t.Setenv("TEST_BACKEND", "s3")
+
+	var buf bytes.Buffer
+	var backend string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().String("backend", "local", "storage backend")
+	cmd.Flags().String("bucket", "", "s3 bucket name")
+	BindEnv(cmd.Flags().Lookup("backend"), "TEST_BACKEND")
+	MarkFlagRequiredIf(cmd.Flags().Lookup("bucket"), "backend", "s3")
+
+	err := Execute(cmd, WithStdout(&buf), WithPreRun(func(c *cobra.Command, _ []string) error {
+		backend = c.Flags().Lookup("backend").Value.String()
+		return nil
+	}))
+
+	// The pre-run sees the default, before the environment variable is applied
+	// and then validated
+	assert.Equal(t, "local", backend)
+	require.EqualError(t, err, "flag --bucket is required when --backend is s3")
+}
+
+func TestExecuteWithPreRunError(t *testing.T) {
+	var buf bytes.Buffer
+	ran := false
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) { ran = true },
+	}
+
+	err := Execute(cmd, WithStdout(&buf), WithPreRun(func(_ *cobra.Command, _ []string) error {
+		return errors.New("config not found")
+	}))
+	require.EqualError(t, err, "config not found")
+	assert.False(t, ran)
+}
+
+func TestExecuteWithArgs(t *testing.T) {
+	var buf bytes.Buffer
+	var message string
+
+	root := &cobra.Command{Use: "myapp"}
+	tag := &cobra.Command{
+		Use: "tag",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			message, _ = cmd.Flags().GetString("message")
+			return nil
+		},
+	}
+	tag.Flags().StringP("message", "m", "", "a custom message for the tag")
+	root.AddCommand(tag)
+
+	err := Execute(root, WithStdout(&buf), WithArgs("tag", "--message", "release"))
+	require.NoError(t, err)
+	assert.Equal(t, "release", message)
+}
+
+func TestExecuteWithStdin(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use: "echo",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := io.Copy(cmd.OutOrStdout(), cmd.InOrStdin())
+			return err
+		},
+	}
+
+	err := Execute(cmd, WithStdout(&buf), WithStdin(bytes.NewReader([]byte("hello from stdin"))), WithArgs())
+	require.NoError(t, err)
+	assert.Equal(t, "hello from stdin", buf.String())
+}
@@ -0,0 +1,63 @@
+// Package clitest provides helpers for testing CLIs built with the cli
+// package.
+package clitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/purpleclay/x/cli"
+	"github.com/spf13/cobra"
+)
+
+// RunCompletion drives carapace's completion machinery against cmd and
+// returns the candidate values for the partial command line in args. The
+// final argument is the word being completed, so pass an empty string to
+// complete the next word. Completion for enum flags is inferred, as it is
+// by [cli.Execute].
+//
+// The command is executed to produce the completions, so build a new command
+// tree for every call.
+//
+//	values, err := clitest.RunCompletion(root, "--format", "")
+//	// values: [json text yaml]
+func RunCompletion(cmd *cobra.Command, args ...string) ([]string, error) {
+	return RunCompletionWith(cmd, nil, args...)
+}
+
+// RunCompletionWith is like [RunCompletion], but first applies the given
+// completions to the command tree, as [cli.WithCompletions] would.
+//
+//	values, err := clitest.RunCompletionWith(root, []cli.CompletionOption{
+//	    cli.CompletePositional(0, cli.Values("dev", "prod")),
+//	}, "")
+//	// values: [dev prod]
+func RunCompletionWith(cmd *cobra.Command, opts []cli.CompletionOption, args ...string) ([]string, error) {
+	var buf bytes.Buffer
+
+	err := cli.Execute(cmd,
+		cli.WithStdout(&buf),
+		cli.WithStderr(io.Discard),
+		cli.WithCompletions(opts...),
+		cli.WithArgs(append([]string{"_carapace", "export", ""}, args...)...))
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		Values []struct {
+			Value string `json:"value"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &export); err != nil {
+		return nil, fmt.Errorf("decoding completions: %w", err)
+	}
+
+	values := make([]string, len(export.Values))
+	for i, v := range export.Values {
+		values[i] = v.Value
+	}
+	return values, nil
+}
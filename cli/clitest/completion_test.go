@@ -0,0 +1,80 @@
+package clitest
+
+import (
+	"testing"
+
+	"github.com/purpleclay/x/cli"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeployCmd() *cobra.Command {
+	root := &cobra.Command{Use: "app"}
+
+	deploy := &cobra.Command{
+		Use: "deploy",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	deploy.Flags().Var(cli.Enum("json", "json", "yaml", "text"), "format", "output format")
+	deploy.Flags().String("region", "", "region to deploy to")
+	root.AddCommand(deploy)
+
+	return root
+}
+
+func TestRunCompletionFlagValue(t *testing.T) {
+	values, err := RunCompletion(newDeployCmd(), "deploy", "--format", "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"json", "yaml", "text"}, values)
+}
+
+func TestRunCompletionFlagValuePrefix(t *testing.T) {
+	values, err := RunCompletion(newDeployCmd(), "deploy", "--format", "y")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"yaml"}, values)
+}
+
+func TestRunCompletionSubcommand(t *testing.T) {
+	values, err := RunCompletion(newDeployCmd(), "dep")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy"}, values)
+}
+
+func TestRunCompletionWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []cli.CompletionOption
+		args     []string
+		expected []string
+	}{
+		{
+			name: "FlagValue",
+			opts: []cli.CompletionOption{
+				cli.CompleteSubcommand("deploy",
+					cli.CompleteFlag("region", cli.Values("eu-west-1", "us-east-1")),
+				),
+			},
+			args:     []string{"deploy", "--region", ""},
+			expected: []string{"eu-west-1", "us-east-1"},
+		},
+		{
+			name: "Positional",
+			opts: []cli.CompletionOption{
+				cli.CompleteSubcommand("deploy",
+					cli.CompletePositional(0, cli.Values("dev", "staging", "prod")),
+				),
+			},
+			args:     []string{"deploy", ""},
+			expected: []string{"dev", "prod", "staging"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := RunCompletionWith(newDeployCmd(), tt.opts, tt.args...)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, values)
+		})
+	}
+}
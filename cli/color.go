@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+)
+
+// colorMode resolves whether styling should be applied to output written to
+// w. NO_COLOR always disables styling, while FORCE_COLOR enables it even when
+// w is not a terminal.
+func colorMode(w io.Writer) (enabled, forced bool) {
+	if os.Getenv("NO_COLOR") != "" {
+		return false, false
+	}
+	if os.Getenv("FORCE_COLOR") != "" {
+		return true, true
+	}
+	return isTerminal(w), false
+}
+
+// isTerminal reports whether w is attached to a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// forceColor ensures styles bound to lipgloss's default renderer emit colors,
+// even if it detected a non-terminal. The returned func restores the previous
+// color profile.
+func forceColor() func() {
+	profile := lipgloss.ColorProfile()
+	if profile != termenv.Ascii {
+		return func() {}
+	}
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	return func() { lipgloss.SetColorProfile(profile) }
+}
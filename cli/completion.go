@@ -1,11 +1,21 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/carapace-sh/carapace"
+	"github.com/carapace-sh/carapace/pkg/cache/key"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -43,20 +53,74 @@ type shellInfo struct {
 	name        string
 	description string
 	example     string
+	// setEnv is a statement setting an environment variable within the shell,
+	// with a %s verb in place of the variable's name
+	setEnv string
 }
 
 var shellRegistry = map[Shell]shellInfo{
-	ShellBash:       {"bash", "Bourne Again Shell", "source <(%s completion bash)"},
-	ShellZsh:        {"zsh", "Z Shell", "source <(%s completion zsh)"},
-	ShellFish:       {"fish", "Friendly Interactive Shell", "%s completion fish | source"},
-	ShellPowerShell: {"powershell", "PowerShell", "%s completion powershell | Out-String | Invoke-Expression"},
-	ShellElvish:     {"elvish", "Elvish", "eval (%s completion elvish | slurp)"},
-	ShellNushell:    {"nushell", "Nushell", "%s completion nushell | save ~/.cache/nushell/completion.nu"},
-	ShellIon:        {"ion", "Ion Shell", "eval $(%s completion ion)"},
-	ShellOil:        {"oil", "Oil Shell", "source <(%s completion oil)"},
-	ShellTcsh:       {"tcsh", "TENEX C Shell", "eval `%s completion tcsh`"},
-	ShellXonsh:      {"xonsh", "Xonsh", "exec($(%s completion xonsh))"},
-	ShellCmd:        {"cmd", "Windows Command Prompt", "%s completion cmd > completion.bat"},
+	ShellBash:       {"bash", "Bourne Again Shell", "source <(%s completion bash)", "export %s=1"},
+	ShellZsh:        {"zsh", "Z Shell", "source <(%s completion zsh)", "export %s=1"},
+	ShellFish:       {"fish", "Friendly Interactive Shell", "%s completion fish | source", "set -gx %s 1"},
+	ShellPowerShell: {"powershell", "PowerShell", "%s completion powershell | Out-String | Invoke-Expression", "$env:%s = '1'"},
+	ShellElvish:     {"elvish", "Elvish", "eval (%s completion elvish | slurp)", "set-env %s 1"},
+	ShellNushell:    {"nushell", "Nushell", "%s completion nushell | save ~/.cache/nushell/completion.nu", "$env.%s = '1'"},
+	ShellIon:        {"ion", "Ion Shell", "eval $(%s completion ion)", "export %s=1"},
+	ShellOil:        {"oil", "Oil Shell", "source <(%s completion oil)", "export %s=1"},
+	ShellTcsh:       {"tcsh", "TENEX C Shell", "eval `%s completion tcsh`", "setenv %s 1"},
+	ShellXonsh:      {"xonsh", "Xonsh", "exec($(%s completion xonsh))", "$%s = '1'"},
+	ShellCmd:        {"cmd", "Windows Command Prompt", "%s completion cmd > completion.bat", "os.setenv('%s', '1')"},
+}
+
+// SupportedShells returns every shell that completion scripts can be
+// generated for, sorted by name.
+func SupportedShells() []Shell {
+	return slices.Sorted(maps.Keys(shellRegistry))
+}
+
+// ShellDetails returns the name and description of shell, along with an
+// example of loading its completion script. The example contains a %s verb
+// in place of the program name. If shell is not supported, ok is false.
+//
+//	_, desc, example, _ := cli.ShellDetails(cli.ShellFish)
+//	fmt.Printf("# %s\n"+example+"\n", desc, "nsv")
+func ShellDetails(shell Shell) (name, description, example string, ok bool) {
+	info, ok := shellRegistry[shell]
+	if !ok {
+		return "", "", "", false
+	}
+	return info.name, info.description, info.example, true
+}
+
+// carapaceShells maps shells to the name carapace knows them by, where the two
+// differ.
+var carapaceShells = map[Shell]string{
+	ShellCmd: "cmd-clink",
+}
+
+// GenerateCompletion writes the completion script for shell to w, without
+// executing the completion command. This suits generating completion scripts
+// at build time, such as when packaging a CLI. Any shell supported by the
+// completion command can be generated.
+//
+//	f, _ := os.Create("completions/nsv.bash")
+//	cli.GenerateCompletion(root, cli.ShellBash, f)
+func GenerateCompletion(root *cobra.Command, shell Shell, w io.Writer) error {
+	if _, ok := shellRegistry[shell]; !ok {
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+
+	name := string(shell)
+	if alias, ok := carapaceShells[shell]; ok {
+		name = alias
+	}
+
+	snippet, err := carapace.Gen(root).Snippet(name)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, snippet)
+	return err
 }
 
 // DefaultShells returns the default set of supported shells.
@@ -135,6 +199,47 @@ func ValuesDescribed(pairs ...string) Completer {
 	return valuesDescribedCompleter{pairs: pairs}
 }
 
+// valuesFuncCompleter completes from a list computed at completion time.
+type valuesFuncCompleter struct {
+	fn        func() []string
+	described bool
+}
+
+func (c valuesFuncCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(func(_ carapace.Context) carapace.Action {
+		if c.described {
+			return carapace.ActionValuesDescribed(c.fn()...)
+		}
+		return carapace.ActionValues(c.fn()...)
+	})
+}
+
+// ValuesFunc returns a [Completer] for a list of values computed by fn.
+// Unlike [Values], fn is only called when completion is requested, making
+// it suitable for values that depend on runtime state such as a config file.
+//
+//	cli.CompleteFlag("profile", cli.ValuesFunc(func() []string {
+//	    return config.Load().ProfileNames()
+//	}))
+func ValuesFunc(fn func() []string) Completer {
+	return valuesFuncCompleter{fn: fn}
+}
+
+// ValuesDescribedFunc returns a [Completer] for values with descriptions
+// computed by fn. Like [ValuesDescribed], fn returns pairs of values and
+// descriptions, but is only called when completion is requested.
+//
+//	cli.CompleteFlag("profile", cli.ValuesDescribedFunc(func() []string {
+//	    var pairs []string
+//	    for _, p := range config.Load().Profiles {
+//	        pairs = append(pairs, p.Name, p.Region)
+//	    }
+//	    return pairs
+//	}))
+func ValuesDescribedFunc(fn func() []string) Completer {
+	return valuesFuncCompleter{fn: fn, described: true}
+}
+
 // executablesCompleter completes executable names.
 type executablesCompleter struct{}
 
@@ -149,6 +254,152 @@ func Executables() Completer {
 	return executablesCompleter{}
 }
 
+// hostnamesCompleter completes hostnames known to SSH.
+type hostnamesCompleter struct{}
+
+func (c hostnamesCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(func(_ carapace.Context) carapace.Action {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return carapace.ActionValues()
+		}
+
+		hosts := knownHosts(filepath.Join(home, ".ssh", "known_hosts"))
+		hosts = append(hosts, sshConfigHosts(filepath.Join(home, ".ssh", "config"))...)
+		return carapace.ActionValues(hosts...).Unique()
+	})
+}
+
+// Hostnames returns a [Completer] for hostnames parsed from the user's
+// ~/.ssh/known_hosts and ~/.ssh/config files. Hashed known_hosts entries
+// and wildcard Host patterns are skipped.
+//
+//	cli.CompleteFlag("host", cli.Hostnames())
+func Hostnames() Completer {
+	return hostnamesCompleter{}
+}
+
+func knownHosts(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var hosts []string
+	for line := range strings.Lines(string(data)) {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "|") {
+			continue
+		}
+
+		for host := range strings.SplitSeq(fields[0], ",") {
+			// Non-standard ports are recorded as [host]:port
+			if strings.HasPrefix(host, "[") {
+				if end := strings.Index(host, "]"); end != -1 {
+					host = host[1:end]
+				}
+			}
+			if host != "" && !strings.ContainsAny(host, "*?!") {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
+
+func sshConfigHosts(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var hosts []string
+	for line := range strings.Lines(string(data)) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "Host") {
+			continue
+		}
+
+		for _, host := range fields[1:] {
+			if !strings.ContainsAny(host, "*?!") {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
+
+// netInterfacesCompleter completes local network interface names.
+type netInterfacesCompleter struct{}
+
+func (c netInterfacesCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(func(_ carapace.Context) carapace.Action {
+		ifaces, err := net.Interfaces()
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+
+		pairs := make([]string, 0, len(ifaces)*2)
+		for _, iface := range ifaces {
+			pairs = append(pairs, iface.Name, iface.Flags.String())
+		}
+		return carapace.ActionValuesDescribed(pairs...)
+	})
+}
+
+// NetInterfaces returns a [Completer] for the names of local network
+// interfaces, described by their flags (e.g. up|broadcast|multicast).
+//
+//	cli.CompleteFlag("interface", cli.NetInterfaces())
+func NetInterfaces() Completer {
+	return netInterfacesCompleter{}
+}
+
+// gitCompleter completes values from the output of a git command.
+type gitCompleter struct {
+	args []string
+}
+
+func (c gitCompleter) toAction() carapace.Action {
+	return carapace.ActionExecCommandE("git", c.args...)(func(output []byte, err error) carapace.Action {
+		// Outside of a repository, or without git installed, offer nothing
+		if err != nil {
+			return carapace.ActionValues()
+		}
+		return carapace.ActionValues(strings.Fields(string(output))...)
+	})
+}
+
+// GitBranches returns a [Completer] for the local branches of the git
+// repository in the current directory. No values are offered if git is
+// not installed or the directory is not a repository.
+//
+//	cli.CompleteFlag("branch", cli.GitBranches())
+func GitBranches() Completer {
+	return gitCompleter{args: []string{"branch", "--format=%(refname:short)"}}
+}
+
+// GitTags returns a [Completer] for the tags of the git repository in the
+// current directory. No values are offered if git is not installed or the
+// directory is not a repository.
+//
+//	cli.CompletePositional(0, cli.GitTags())
+func GitTags() Completer {
+	return gitCompleter{args: []string{"tag", "--list"}}
+}
+
+// GitRemotes returns a [Completer] for the remotes of the git repository in
+// the current directory. No values are offered if git is not installed or
+// the directory is not a repository.
+//
+//	cli.CompleteFlag("remote", cli.GitRemotes())
+func GitRemotes() Completer {
+	return gitCompleter{args: []string{"remote"}}
+}
+
 // noneCompleter disables completion.
 type noneCompleter struct{}
 
@@ -187,6 +438,184 @@ func ActionFunc(fn func() carapace.Action) Completer {
 	return actionFuncCompleter{fn: fn}
 }
 
+// callbackCompleter wraps a function that receives the completion context.
+type callbackCompleter struct {
+	fn func(carapace.Context) carapace.Action
+}
+
+func (c callbackCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(c.fn)
+}
+
+// cacheCompleter caches the results of another completer.
+type cacheCompleter struct {
+	ttl   time.Duration
+	inner Completer
+	key   string
+}
+
+func (c cacheCompleter) toAction() carapace.Action {
+	return c.inner.toAction().Cache(c.ttl, key.String(c.key))
+}
+
+// Cache returns a [Completer] that caches the results of inner for the given
+// duration. Results are written to the user's cache directory and reused
+// across shell sessions until the ttl expires, at which point inner is
+// invoked again. A negative ttl caches results indefinitely. Results that
+// contain an error message are never cached.
+//
+// Only dynamic completers such as [ActionFunc] benefit from caching, static
+// completers like [Values] are unaffected.
+//
+//	cli.CompleteFlag("branch", cli.Cache(5*time.Minute, cli.ActionFunc(func() carapace.Action {
+//	    return carapace.ActionExecCommand("git", "branch", "--format=%(refname:short)")(
+//	        func(output []byte) carapace.Action {
+//	            return carapace.ActionValues(strings.Fields(string(output))...)
+//	        },
+//	    )
+//	})))
+func Cache(ttl time.Duration, inner Completer) Completer {
+	// Each call site is cached independently, mirroring carapace
+	_, file, line, _ := runtime.Caller(1)
+	return cacheCompleter{ttl: ttl, inner: inner, key: fmt.Sprintf("%s:%d", file, line)}
+}
+
+// filterCompleter removes values from another completer.
+type filterCompleter struct {
+	inner   Completer
+	exclude []string
+}
+
+func (c filterCompleter) toAction() carapace.Action {
+	return c.inner.toAction().Filter(c.exclude...)
+}
+
+// Filter returns a [Completer] that removes the given values from the
+// results of inner. Filtering is applied after inner has produced its
+// values, so it composes with any other completer, including [Cache].
+//
+//	cli.CompleteFlag("env", cli.Filter(cli.Values("dev", "staging", "prod"), "prod"))
+func Filter(inner Completer, exclude ...string) Completer {
+	return filterCompleter{inner: inner, exclude: exclude}
+}
+
+// filterFuncCompleter removes values from another completer using a predicate.
+type filterFuncCompleter struct {
+	inner Completer
+	fn    func(string) bool
+}
+
+func (c filterFuncCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(func(ctx carapace.Context) carapace.Action {
+		invoked := c.inner.toAction().Invoke(ctx)
+
+		var exclude []string
+		for _, v := range invokedValues(invoked) {
+			if c.fn(v) {
+				exclude = append(exclude, v)
+			}
+		}
+		return invoked.Filter(exclude...).ToA()
+	})
+}
+
+// FilterFunc returns a [Completer] that removes any value from the results
+// of inner for which fn returns true. Like [Filter], it is applied after
+// inner has produced its values.
+//
+//	cli.CompleteFlag("config", cli.FilterFunc(cli.Files(), func(f string) bool {
+//	    return strings.HasPrefix(f, ".")
+//	}))
+func FilterFunc(inner Completer, fn func(string) bool) Completer {
+	return filterFuncCompleter{inner: inner, fn: fn}
+}
+
+// messageCompleter surfaces a message instead of completing values.
+type messageCompleter struct {
+	msg string
+}
+
+func (c messageCompleter) toAction() carapace.Action {
+	return carapace.ActionMessage(c.msg)
+}
+
+// Message returns a [Completer] that shows an inline message rather than
+// values, such as explaining why nothing can be completed. Combine it with
+// [Fallback] to only show the message when another completer has no values.
+//
+//	cli.CompleteFlag("project", cli.Message("run %s login to list projects", "myapp"))
+func Message(format string, args ...any) Completer {
+	return messageCompleter{msg: fmt.Sprintf(format, args...)}
+}
+
+// fallbackCompleter switches to another completer when the first has no values.
+type fallbackCompleter struct {
+	inner    Completer
+	fallback Completer
+}
+
+func (c fallbackCompleter) toAction() carapace.Action {
+	return carapace.ActionCallback(func(ctx carapace.Context) carapace.Action {
+		invoked := c.inner.toAction().Invoke(ctx)
+		if len(invokedValues(invoked)) == 0 {
+			return c.fallback.toAction()
+		}
+		return invoked.ToA()
+	})
+}
+
+// Fallback returns a [Completer] that uses fallback whenever inner produces
+// no values. It pairs well with [Message] to avoid silent empty completions.
+//
+//	cli.CompleteFlag("branch", cli.Fallback(cli.GitBranches(), cli.Message("not a git repository")))
+func Fallback(inner, fallback Completer) Completer {
+	return fallbackCompleter{inner: inner, fallback: fallback}
+}
+
+// mergeCompleter unions the results of several completers.
+type mergeCompleter struct {
+	completers []Completer
+}
+
+func (c mergeCompleter) toAction() carapace.Action {
+	actions := make([]carapace.Action, len(c.completers))
+	for i, completer := range c.completers {
+		actions[i] = completer.toAction()
+	}
+	return carapace.Batch(actions...).ToA()
+}
+
+// Merge returns a [Completer] offering the values of every given completer,
+// in the order provided.
+//
+//	cli.CompleteFlag("input", cli.Merge(cli.Values("-"), cli.Files(".json")))
+func Merge(completers ...Completer) Completer {
+	return mergeCompleter{completers: completers}
+}
+
+// invokedValues extracts the completion values from an invoked action.
+func invokedValues(ia carapace.InvokedAction) []string {
+	data, err := ia.MarshalJSON()
+	if err != nil {
+		return nil
+	}
+
+	var export struct {
+		Values []struct {
+			Value string `json:"value"`
+		} `json:"values"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil
+	}
+
+	values := make([]string, len(export.Values))
+	for i, v := range export.Values {
+		values[i] = v.Value
+	}
+	return values
+}
+
 // CompletionOption configures shell completion behavior.
 type CompletionOption func(*completionOptions)
 
@@ -196,6 +625,11 @@ type completionOptions struct {
 	positional    map[int]Completer
 	positionalAny Completer
 	subcommands   map[string]*completionOptions
+	install       bool
+	hooks         map[Shell][]string
+	short         string
+	long          string
+	hidden        bool
 }
 
 func defaultCompletionOptions() *completionOptions {
@@ -227,7 +661,73 @@ func WithExtraShells(shells ...Shell) CompletionOption {
 	}
 }
 
-// CompleteFlag defines completion for a flag.
+// WithCompletionInstall adds "install" and "uninstall" subcommands to the
+// completion command. Install writes the generated script to the conventional
+// location for the shell, creating any missing directories, and uninstall
+// removes it again. Only bash, zsh and fish are supported. Scripts are written
+// to $XDG_DATA_HOME/bash-completion/completions, ~/.zfunc (which must be within
+// your fpath) and $XDG_CONFIG_HOME/fish/completions respectively.
+//
+//	cli.WithCompletionCommand(
+//	    cli.WithCompletionInstall(),
+//	)
+func WithCompletionInstall() CompletionOption {
+	return func(o *completionOptions) {
+		o.install = true
+	}
+}
+
+// WithCompletionDescription replaces the short and long descriptions of the
+// completion command, so its help matches the tone of the rest of the CLI.
+// An empty description keeps the default.
+//
+//	cli.WithCompletionCommand(
+//	    cli.WithCompletionDescription(
+//	        "Set up tab completion for nsv",
+//	        "Print a script that enables tab completion for nsv within your shell.",
+//	    ),
+//	)
+func WithCompletionDescription(short, long string) CompletionOption {
+	return func(o *completionOptions) {
+		o.short = short
+		o.long = long
+	}
+}
+
+// WithHiddenCompletionCommand hides the completion command from help, while
+// leaving it available to generate and install completion scripts.
+//
+//	cli.WithCompletionCommand(
+//	    cli.WithHiddenCompletionCommand(),
+//	)
+func WithHiddenCompletionCommand() CompletionOption {
+	return func(o *completionOptions) {
+		o.hidden = true
+	}
+}
+
+// WithShellHook appends snippet to the completion script generated for
+// shell, such as configuring how completions are displayed. Hooks are
+// appended in the order they are provided. Panics if shell is unknown.
+//
+//	cli.WithCompletionCommand(
+//	    cli.WithShellHook(cli.ShellZsh, "zstyle ':completion:*:nsv:*' group-name ''"),
+//	)
+func WithShellHook(shell Shell, snippet string) CompletionOption {
+	if _, ok := shellRegistry[shell]; !ok {
+		panic(fmt.Sprintf("cli: unsupported shell: %s", shell))
+	}
+
+	return func(o *completionOptions) {
+		if o.hooks == nil {
+			o.hooks = make(map[Shell][]string)
+		}
+		o.hooks[shell] = append(o.hooks[shell], snippet)
+	}
+}
+
+// CompleteFlag defines completion for a flag. Completion for a persistent
+// flag also applies to every subcommand that inherits it.
 //
 //	cli.WithCompletionCommand(
 //	    cli.CompleteFlag("config", cli.Files(".yaml", ".json")),
@@ -242,6 +742,23 @@ func CompleteFlag(flag string, completer Completer) CompletionOption {
 	}
 }
 
+// CompleteFlagFunc defines completion for a flag that depends on the rest of
+// the command line. The function is invoked at completion time with the
+// carapace context, exposing the positional arguments already typed. Any
+// flags already typed have been parsed and can be read from the command.
+//
+//	cli.WithCompletionCommand(
+//	    cli.CompleteFlagFunc("region", func(c carapace.Context) carapace.Action {
+//	        if len(c.Args) > 0 && c.Args[0] == "aws" {
+//	            return carapace.ActionValues("eu-west-1", "us-east-1")
+//	        }
+//	        return carapace.ActionValues("europe-west1", "us-central1")
+//	    }),
+//	)
+func CompleteFlagFunc(flag string, fn func(c carapace.Context) carapace.Action) CompletionOption {
+	return CompleteFlag(flag, callbackCompleter{fn: fn})
+}
+
 // CompletePositional defines completion for a positional argument (0-indexed).
 //
 //	cli.WithCompletionCommand(
@@ -256,6 +773,22 @@ func CompletePositional(position int, completer Completer) CompletionOption {
 	}
 }
 
+// CompletePositionalRange defines the same completion for each positional
+// argument within the inclusive range from..to (0-indexed). Positions before
+// from without their own completion offer no values.
+//
+//	cli.WithCompletionCommand(
+//	    cli.CompletePositional(0, cli.Values("copy", "move")),
+//	    cli.CompletePositionalRange(1, 3, cli.Directories()),
+//	)
+func CompletePositionalRange(from, to int, completer Completer) CompletionOption {
+	return func(o *completionOptions) {
+		for pos := from; pos <= to; pos++ {
+			CompletePositional(pos, completer)(o)
+		}
+	}
+}
+
 // CompletePositionalAny defines completion for remaining positional arguments.
 //
 //	cli.WithCompletionCommand(
@@ -288,6 +821,99 @@ func CompleteSubcommand(name string, opts ...CompletionOption) CompletionOption
 	}
 }
 
+// noDescriptionsEnv returns the environment variable that disables the
+// descriptions of completion values for the CLI, such as
+// NSV_COMPLETION_NO_DESCRIPTIONS. It is set within the shell by completion
+// scripts generated with the --no-descriptions flag, and read whenever the
+// scripts call back into the _carapace command.
+func noDescriptionsEnv(root *cobra.Command) string {
+	return envVarName("", root.Name()+"-completion-no-descriptions")
+}
+
+func registerCompletions(root *cobra.Command, opts *completionOptions, command bool) {
+	// Generated scripts call back into the hidden _carapace command on the
+	// root, so it must exist even if the root has no completions of its own
+	noDescriptions := noDescriptionsEnv(root)
+	carapace.Gen(root).PreInvoke(func(_ *cobra.Command, _ *pflag.Flag, action carapace.Action) carapace.Action {
+		if os.Getenv(noDescriptions) == "" {
+			return action
+		}
+		return withoutDescriptions(action)
+	})
+
+	carapace.Gen(root).PreRun(func(_ *cobra.Command, _ []string) {
+		markConflictsExclusive(root)
+	})
+
+	if command {
+		root.AddCommand(newCompletionCommand(opts, root.Name()))
+	}
+	applyCompletions(root, opts)
+}
+
+// cobraMutuallyExclusive is the annotation cobra uses to record a group of
+// mutually exclusive flags, which carapace honors during flag completion.
+const cobraMutuallyExclusive = "cobra_annotation_mutually_exclusive"
+
+// markConflictsExclusive records flags marked with [MarkFlagConflicts] as
+// mutually exclusive, so a conflicting flag is no longer offered during
+// completion once its partner is present. This is only applied when
+// completing, leaving validation to [MarkFlagConflicts].
+func markConflictsExclusive(cmd *cobra.Command) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		for _, name := range GetFlagConflicts(f) {
+			other := cmd.LocalFlags().Lookup(name)
+			if other == nil {
+				other = cmd.InheritedFlags().Lookup(name)
+			}
+			if other == nil {
+				continue
+			}
+
+			group := f.Name + " " + other.Name
+			for _, flag := range []*pflag.Flag{f, other} {
+				if flag.Annotations == nil {
+					flag.Annotations = make(map[string][]string)
+				}
+				flag.Annotations[cobraMutuallyExclusive] = append(flag.Annotations[cobraMutuallyExclusive], group)
+			}
+		}
+	})
+
+	for _, sub := range cmd.Commands() {
+		markConflictsExclusive(sub)
+	}
+}
+
+// withoutDescriptions strips the descriptions from the values of action. The
+// action is round-tripped through its export format, retaining everything
+// else about it.
+func withoutDescriptions(action carapace.Action) carapace.Action {
+	return carapace.ActionCallback(func(c carapace.Context) carapace.Action {
+		data, err := action.Invoke(c).MarshalJSON()
+		if err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+
+		var export map[string]any
+		if err := json.Unmarshal(data, &export); err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+
+		values, _ := export["values"].([]any)
+		for _, v := range values {
+			if value, ok := v.(map[string]any); ok {
+				delete(value, "description")
+			}
+		}
+
+		if data, err = json.Marshal(export); err != nil {
+			return carapace.ActionMessage(err.Error())
+		}
+		return carapace.ActionImport(data)
+	})
+}
+
 func applyCompletions(cmd *cobra.Command, opts *completionOptions) {
 	if opts == nil {
 		return
@@ -326,22 +952,38 @@ func applyCompletions(cmd *cobra.Command, opts *completionOptions) {
 	}
 
 	for _, sub := range cmd.Commands() {
-		if subOpts, ok := opts.subcommands[sub.Name()]; ok {
-			applyCompletions(sub, subOpts)
+		// Subcommands without completions still need their enum flags inferred
+		subOpts, ok := opts.subcommands[sub.Name()]
+		if !ok {
+			subOpts = &completionOptions{}
 		}
+		applyCompletions(sub, subOpts)
 	}
 }
 
 func inferFlagCompletions(cmd *cobra.Command) carapace.ActionMap {
 	actions := make(carapace.ActionMap)
 
-	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+	// Persistent flags are completed on every subcommand that inherits them
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
 		if helper, ok := f.Value.(EnumHelper); ok {
 			var values []string
 			for _, entry := range helper.HelpEntries() {
-				values = append(values, entry.Name)
+				if helper.HasHelp() {
+					values = append(values, entry.Name, entry.Help)
+				} else {
+					values = append(values, entry.Name)
+				}
+			}
+
+			action := carapace.ActionValues(values...)
+			if helper.HasHelp() {
+				action = carapace.ActionValuesDescribed(values...)
 			}
-			actions[f.Name] = carapace.ActionValues(values...)
+			if _, ok := f.Value.(pflag.SliceValue); ok {
+				action = action.UniqueList(",")
+			}
+			actions[f.Name] = action
 		}
 	})
 
@@ -366,42 +1008,354 @@ func newCompletionCommand(opts *completionOptions, rootName string) *cobra.Comma
 	}
 
 	cmd := &cobra.Command{
-		Use:   "completion <shell>",
+		Use:   "completion [shell]",
 		Short: "Generate shell completion scripts for your shell",
-		Long: fmt.Sprintf(`Generate shell completion scripts for your shell.
+		Long: fmt.Sprintf(`Generate shell completion scripts for your shell. If no shell is
+provided, it is detected from the SHELL environment variable.
 
 Supported shells: %s`, strings.Join(validArgs, ", ")),
 		Example:               examples.String(),
 		DisableFlagsInUseLine: true,
 		ValidArgs:             validArgs,
-		Args:                  cobra.ExactArgs(1),
+		Args:                  cobra.MaximumNArgs(1),
 		Annotations: map[string]string{
 			"hideInheritedFlags": "true",
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			shell := args[0]
-			valid := false
-			for _, s := range opts.shells {
-				if string(s) == shell {
-					valid = true
-					break
+			var shell string
+			if len(args) > 0 {
+				shell = args[0]
+			} else {
+				var err error
+				if shell, err = detectShell(opts, validArgs); err != nil {
+					return err
 				}
 			}
-			if !valid {
+
+			if !opts.supports(shell) {
 				return fmt.Errorf("unsupported shell: %s", shell)
 			}
-			snippet, err := carapace.Gen(cmd.Root()).Snippet(shell)
+			noDescriptions, _ := cmd.Flags().GetBool("no-descriptions")
+			script, err := opts.script(cmd.Root(), Shell(shell), !noDescriptions)
 			if err != nil {
 				return err
 			}
-			fmt.Fprint(cmd.OutOrStdout(), snippet)
+			fmt.Fprint(cmd.OutOrStdout(), script)
 			return nil
 		},
 	}
+	cmd.Flags().Bool("no-descriptions", false, "disable descriptions for completion values")
+
+	if opts.short != "" {
+		cmd.Short = opts.short
+	}
+	if opts.long != "" {
+		cmd.Long = opts.long
+	}
+	cmd.Hidden = opts.hidden
 
 	carapace.Gen(cmd).PositionalCompletion(
 		carapace.ActionValuesDescribed(descPairs...),
 	)
 
+	if opts.install {
+		cmd.AddCommand(
+			newCompletionInstallCommand(opts, validArgs, descPairs),
+			newCompletionUninstallCommand(opts, validArgs, descPairs),
+		)
+	}
+	cmd.AddCommand(newCompletionDebugCommand(opts))
+
 	return cmd
 }
+
+func (o *completionOptions) supports(shell string) bool {
+	for _, s := range o.shells {
+		if string(s) == shell {
+			return true
+		}
+	}
+	return false
+}
+
+// shellAliases maps the names of shell executables to the shell they run,
+// where the two differ.
+var shellAliases = map[string]Shell{
+	"pwsh": ShellPowerShell,
+	"nu":   ShellNushell,
+	"osh":  ShellOil,
+}
+
+// detectShell identifies the shell of the user from the SHELL environment
+// variable, returning an error if it cannot be detected or is not supported.
+func detectShell(opts *completionOptions, supported []string) (string, error) {
+	path := os.Getenv("SHELL")
+	if path == "" {
+		return "", fmt.Errorf("unable to detect shell, specify one of: %s", strings.Join(supported, ", "))
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), ".exe")
+	if alias, ok := shellAliases[name]; ok {
+		name = string(alias)
+	}
+
+	if !opts.supports(name) {
+		return "", fmt.Errorf("detected shell %s is not supported, specify one of: %s", name, strings.Join(supported, ", "))
+	}
+	return name, nil
+}
+
+// script generates the completion script for shell, appending any hooks. If
+// descriptions are disabled, the script sets the environment variable returned
+// by [noDescriptionsEnv] within the shell before anything else runs.
+func (o *completionOptions) script(root *cobra.Command, shell Shell, descriptions bool) (string, error) {
+	var buf strings.Builder
+	if err := GenerateCompletion(root, shell, &buf); err != nil {
+		return "", err
+	}
+
+	script := buf.String()
+	if !descriptions {
+		setEnv := fmt.Sprintf(shellRegistry[shell].setEnv, noDescriptionsEnv(root))
+		script = insertAfterHeader(script, setEnv)
+	}
+
+	for _, hook := range o.hooks[shell] {
+		if !strings.HasSuffix(script, "\n") {
+			script += "\n"
+		}
+		script += hook
+	}
+	return script, nil
+}
+
+// insertAfterHeader inserts line into script after any leading lines that a
+// shell requires to open it, such as a zsh #compdef directive or PowerShell
+// using statements.
+func insertAfterHeader(script, line string) string {
+	var header strings.Builder
+	for {
+		first, rest, ok := strings.Cut(script, "\n")
+		if !ok || !(strings.HasPrefix(first, "#") || strings.HasPrefix(first, "using ")) {
+			break
+		}
+		header.WriteString(first + "\n")
+		script = rest
+	}
+	return header.String() + line + "\n" + script
+}
+
+func newCompletionInstallCommand(opts *completionOptions, validArgs, descPairs []string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "install <shell>",
+		Short:                 "Install the completion script for your shell",
+		DisableFlagsInUseLine: true,
+		ValidArgs:             validArgs,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := args[0]
+			if !opts.supports(shell) {
+				return fmt.Errorf("unsupported shell: %s", shell)
+			}
+
+			root := cmd.Root()
+			path, err := completionInstallPath(Shell(shell), root.Name())
+			if err != nil {
+				return err
+			}
+
+			noDescriptions, _ := cmd.Flags().GetBool("no-descriptions")
+			script, err := opts.script(root, Shell(shell), !noDescriptions)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return fmt.Errorf("creating completion directory: %w", err)
+			}
+			if err := os.WriteFile(path, []byte(script), 0o644); err != nil {
+				return fmt.Errorf("writing completion script: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "installed %s completion to %s\n", shell, path)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("no-descriptions", false, "disable descriptions for completion values")
+
+	carapace.Gen(cmd).PositionalCompletion(
+		carapace.ActionValuesDescribed(descPairs...),
+	)
+
+	return cmd
+}
+
+func newCompletionUninstallCommand(opts *completionOptions, validArgs, descPairs []string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "uninstall <shell>",
+		Short:                 "Remove an installed completion script for your shell",
+		DisableFlagsInUseLine: true,
+		ValidArgs:             validArgs,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := args[0]
+			if !opts.supports(shell) {
+				return fmt.Errorf("unsupported shell: %s", shell)
+			}
+
+			path, err := completionInstallPath(Shell(shell), cmd.Root().Name())
+			if err != nil {
+				return err
+			}
+
+			if err := os.Remove(path); err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("no %s completion installed at %s", shell, path)
+				}
+				return fmt.Errorf("removing completion script: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "removed %s completion from %s\n", shell, path)
+			return nil
+		},
+	}
+
+	carapace.Gen(cmd).PositionalCompletion(
+		carapace.ActionValuesDescribed(descPairs...),
+	)
+
+	return cmd
+}
+
+// completionInstallPath returns the conventional location a shell loads
+// completion scripts from for the named program.
+func completionInstallPath(shell Shell, name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case ShellBash:
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions", name), nil
+	case ShellZsh:
+		return filepath.Join(home, ".zfunc", "_"+name), nil
+	case ShellFish:
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "fish", "completions", name+".fish"), nil
+	default:
+		return "", fmt.Errorf("completion install is not supported for shell: %s", shell)
+	}
+}
+
+func newCompletionDebugCommand(opts *completionOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:                   "_debug",
+		Short:                 "Print the completions registered for every command",
+		DisableFlagsInUseLine: true,
+		Hidden:                true,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			writeCompletionDebug(tw, cmd.Root(), opts, 0)
+			return tw.Flush()
+		},
+	}
+}
+
+// writeCompletionDebug prints a tree of the flag and positional completions
+// applied to each visible command, mirroring applyCompletions.
+func writeCompletionDebug(w io.Writer, cmd *cobra.Command, opts *completionOptions, depth int) {
+	indent := strings.Repeat("  ", depth)
+	fmt.Fprintf(w, "%s%s\n", indent, cmd.Name())
+
+	flags := make(map[string]string)
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if helper, ok := f.Value.(EnumHelper); ok {
+			names := make([]string, 0, len(helper.HelpEntries()))
+			for _, entry := range helper.HelpEntries() {
+				names = append(names, entry.Name)
+			}
+			flags[f.Name] = "enum(" + strings.Join(names, ", ") + ")"
+		}
+	})
+	for name, completer := range opts.flags {
+		flags[name] = describeCompleter(completer)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		fmt.Fprintf(w, "%s  --%s\t%s\n", indent, name, flags[name])
+	}
+	for _, pos := range slices.Sorted(maps.Keys(opts.positional)) {
+		fmt.Fprintf(w, "%s  [%d]\t%s\n", indent, pos, describeCompleter(opts.positional[pos]))
+	}
+	if opts.positionalAny != nil {
+		fmt.Fprintf(w, "%s  [*]\t%s\n", indent, describeCompleter(opts.positionalAny))
+	}
+
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		subOpts, ok := opts.subcommands[sub.Name()]
+		if !ok {
+			subOpts = &completionOptions{}
+		}
+		writeCompletionDebug(w, sub, subOpts, depth+1)
+	}
+}
+
+// describeCompleter returns a readable summary of a completer.
+func describeCompleter(c Completer) string {
+	switch c := c.(type) {
+	case filesCompleter:
+		return "files(" + strings.Join(c.extensions, ", ") + ")"
+	case directoriesCompleter:
+		return "directories"
+	case valuesCompleter:
+		return "values(" + strings.Join(c.values, ", ") + ")"
+	case valuesDescribedCompleter:
+		var values []string
+		for i := 0; i < len(c.pairs); i += 2 {
+			values = append(values, c.pairs[i])
+		}
+		return "values(" + strings.Join(values, ", ") + ")"
+	case valuesFuncCompleter:
+		return "values(func)"
+	case executablesCompleter:
+		return "executables"
+	case hostnamesCompleter:
+		return "hostnames"
+	case netInterfacesCompleter:
+		return "net interfaces"
+	case gitCompleter:
+		return "git " + c.args[0]
+	case noneCompleter:
+		return "none"
+	case cacheCompleter:
+		return fmt.Sprintf("cache(%s, %s)", c.ttl, describeCompleter(c.inner))
+	case filterCompleter:
+		return fmt.Sprintf("filter(%s, excluding %s)", describeCompleter(c.inner), strings.Join(c.exclude, ", "))
+	case filterFuncCompleter:
+		return fmt.Sprintf("filter(%s, func)", describeCompleter(c.inner))
+	case mergeCompleter:
+		names := make([]string, len(c.completers))
+		for i, completer := range c.completers {
+			names[i] = describeCompleter(completer)
+		}
+		return "merge(" + strings.Join(names, ", ") + ")"
+	case messageCompleter:
+		return fmt.Sprintf("message(%q)", c.msg)
+	case fallbackCompleter:
+		return fmt.Sprintf("fallback(%s, %s)", describeCompleter(c.inner), describeCompleter(c.fallback))
+	default:
+		return "func"
+	}
+}
@@ -2,7 +2,14 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/carapace-sh/carapace"
 	"github.com/spf13/cobra"
@@ -82,6 +89,63 @@ func TestCompletionSubcommandHelpExtraShells(t *testing.T) {
 	golden.Assert(t, buf.String(), "completion_help_extra_shells.golden")
 }
 
+func TestCompletionSubcommandHelpCustomDescription(t *testing.T) {
+	opts := []CompletionOption{
+		WithCompletionDescription("Set up tab completion for nsv", "Print a script that enables tab completion for nsv."),
+	}
+
+	var buf bytes.Buffer
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(opts...))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), "Print a script that enables tab completion for nsv.\n"))
+	assert.NotContains(t, buf.String(), "Supported shells")
+
+	buf.Reset()
+	root = newRootCmd()
+	root.SetArgs([]string{"--help"})
+
+	err = Execute(root, WithStdout(&buf), WithCompletionCommand(opts...))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "completion    Set up tab completion for nsv")
+}
+
+func TestCompletionSubcommandHelpEmptyDescription(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(
+		WithCompletionDescription("", ""),
+	))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "completion_help.golden")
+}
+
+func TestCompletionHiddenCommand(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(WithHiddenCompletionCommand()))
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "completion")
+	assert.NotContains(t, buf.String(), "COMMANDS")
+
+	buf.Reset()
+	root = newRootCmd()
+	root.SetArgs([]string{"completion", "bash"})
+
+	err = Execute(root, WithStdout(&buf), WithCompletionCommand(WithHiddenCompletionCommand()))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "_nsv_completion")
+}
+
 func TestCompletionGeneratesBashScript(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -123,6 +187,231 @@ func TestCompletionGeneratesFishScript(t *testing.T) {
 	assert.True(t, len(output) > 0, "completion script should not be empty")
 }
 
+func TestCompletionWithShellHook(t *testing.T) {
+	hook := "zstyle ':completion:*:nsv:*' group-name ''"
+
+	tests := []struct {
+		name     string
+		shell    string
+		expected bool
+	}{
+		{name: "Zsh", shell: "zsh", expected: true},
+		{name: "Bash", shell: "bash", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.SetArgs([]string{"completion", tt.shell})
+
+			err := Execute(root, WithStdout(&buf), WithCompletionCommand(WithShellHook(ShellZsh, hook)))
+			require.NoError(t, err)
+
+			if tt.expected {
+				assert.True(t, strings.HasSuffix(buf.String(), "\n"+hook))
+			} else {
+				assert.NotContains(t, buf.String(), hook)
+			}
+		})
+	}
+}
+
+func TestWithShellHookUnknownShell(t *testing.T) {
+	assert.PanicsWithValue(t, "cli: unsupported shell: csh", func() {
+		WithShellHook(Shell("csh"), "echo")
+	})
+}
+
+func TestCompletionDetectsShell(t *testing.T) {
+	t.Setenv("SHELL", "/bin/zsh")
+
+	var detected, explicit bytes.Buffer
+
+	root := newRootCmd()
+	err := Execute(root, WithStdout(&detected), WithCompletionCommand(), WithArgs("completion"))
+	require.NoError(t, err)
+
+	root = newRootCmd()
+	err = Execute(root, WithStdout(&explicit), WithCompletionCommand(), WithArgs("completion", "zsh"))
+	require.NoError(t, err)
+
+	assert.Contains(t, detected.String(), "#compdef nsv")
+	assert.Equal(t, explicit.String(), detected.String())
+}
+
+func TestCompletionDetectShellErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell string
+		err   string
+	}{
+		{name: "Unset", shell: "", err: "unable to detect shell, specify one of: bash, zsh, fish"},
+		{name: "Unsupported", shell: "/usr/bin/pwsh", err: "detected shell powershell is not supported, specify one of: bash, zsh, fish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SHELL", tt.shell)
+
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			err := Execute(root, WithStdout(&buf), WithStderr(&buf), WithCompletionCommand(), WithArgs("completion"))
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestCompletionNoDescriptions(t *testing.T) {
+	tests := []struct {
+		shell  Shell
+		setEnv string
+		line   int
+	}{
+		{shell: ShellBash, setEnv: "export NSV_COMPLETION_NO_DESCRIPTIONS=1", line: 1},
+		{shell: ShellZsh, setEnv: "export NSV_COMPLETION_NO_DESCRIPTIONS=1", line: 1},
+		{shell: ShellFish, setEnv: "set -gx NSV_COMPLETION_NO_DESCRIPTIONS 1", line: 0},
+		{shell: ShellPowerShell, setEnv: "$env:NSV_COMPLETION_NO_DESCRIPTIONS = '1'", line: 2},
+		{shell: ShellElvish, setEnv: "set-env NSV_COMPLETION_NO_DESCRIPTIONS 1", line: 0},
+		{shell: ShellNushell, setEnv: "$env.NSV_COMPLETION_NO_DESCRIPTIONS = '1'", line: 0},
+		{shell: ShellIon, setEnv: "export NSV_COMPLETION_NO_DESCRIPTIONS=1", line: 0},
+		{shell: ShellOil, setEnv: "export NSV_COMPLETION_NO_DESCRIPTIONS=1", line: 1},
+		{shell: ShellTcsh, setEnv: "setenv NSV_COMPLETION_NO_DESCRIPTIONS 1", line: 0},
+		{shell: ShellXonsh, setEnv: "$NSV_COMPLETION_NO_DESCRIPTIONS = '1'", line: 0},
+		{shell: ShellCmd, setEnv: "os.setenv('NSV_COMPLETION_NO_DESCRIPTIONS', '1')", line: 0},
+	}
+	require.Len(t, tests, len(shellRegistry))
+
+	generate := func(t *testing.T, args ...string) []string {
+		t.Helper()
+
+		var buf bytes.Buffer
+		root := newRootCmd()
+		err := Execute(root, WithStdout(&buf), WithCompletionCommand(WithExtraShells(SupportedShells()...)), WithArgs(args...))
+		require.NoError(t, err)
+		return strings.Split(buf.String(), "\n")
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.shell), func(t *testing.T) {
+			enabled := generate(t, "completion", string(tt.shell))
+			disabled := generate(t, "completion", string(tt.shell), "--no-descriptions")
+
+			require.Equal(t, tt.setEnv, disabled[tt.line])
+			assert.Equal(t, enabled, slices.Delete(disabled, tt.line, tt.line+1))
+		})
+	}
+}
+
+func TestCompletionInstallNoDescriptions(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	root := newRootCmd()
+	err := Execute(root, WithStdout(&bytes.Buffer{}),
+		WithCompletionCommand(WithCompletionInstall()),
+		WithArgs("completion", "install", "zsh", "--no-descriptions"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(home, ".zfunc", "_nsv"))
+	require.NoError(t, err)
+
+	lines := strings.Split(string(data), "\n")
+	assert.Equal(t, "#compdef nsv", lines[0])
+	assert.Equal(t, "export NSV_COMPLETION_NO_DESCRIPTIONS=1", lines[1])
+}
+
+func TestCompletionValuesWithoutDescriptions(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          string
+		descriptions []string
+	}{
+		{name: "Enabled", env: "", descriptions: []string{"JSON format", "YAML format"}},
+		{name: "Disabled", env: "1", descriptions: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NSV_COMPLETION_NO_DESCRIPTIONS", tt.env)
+
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.Flags().String("format", "", "output format")
+			root.SetArgs([]string{"_carapace", "export", "", "--format", ""})
+
+			err := Execute(root, WithStdout(&buf), WithCompletionCommand(
+				CompleteFlag("format", ValuesDescribed("json", "JSON format", "yaml", "YAML format")),
+			))
+			require.NoError(t, err)
+
+			var export struct {
+				Values []struct {
+					Value       string `json:"value"`
+					Description string `json:"description"`
+				} `json:"values"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &export), buf.String())
+			require.Len(t, export.Values, 2)
+
+			for i, v := range export.Values {
+				assert.Equal(t, tt.descriptions[i], v.Description)
+			}
+		})
+	}
+}
+
+func TestSupportedShells(t *testing.T) {
+	shells := SupportedShells()
+
+	assert.Len(t, shells, len(shellRegistry))
+	assert.True(t, slices.IsSorted(shells))
+	for _, shell := range shells {
+		_, _, _, ok := ShellDetails(shell)
+		assert.True(t, ok, shell)
+	}
+}
+
+func TestShellDetails(t *testing.T) {
+	name, description, example, ok := ShellDetails(ShellFish)
+	require.True(t, ok)
+
+	assert.Equal(t, "fish", name)
+	assert.Equal(t, "Friendly Interactive Shell", description)
+	assert.Equal(t, "%s completion fish | source", example)
+}
+
+func TestShellDetailsUnknownShell(t *testing.T) {
+	name, description, example, ok := ShellDetails(Shell("csh"))
+	require.False(t, ok)
+
+	assert.Empty(t, name)
+	assert.Empty(t, description)
+	assert.Empty(t, example)
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := GenerateCompletion(newRootCmd(), ShellBash, &buf)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "complete -o noquote -F _nsv_completion nsv")
+}
+
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := GenerateCompletion(newRootCmd(), Shell("csh"), &buf)
+	require.EqualError(t, err, "unsupported shell: csh")
+	assert.Empty(t, buf.String())
+}
+
 func TestCompletionRejectsUnconfiguredShell(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -149,6 +438,99 @@ func TestCompletionAcceptsConfiguredShell(t *testing.T) {
 	assert.True(t, len(output) > 0, "completion script should not be empty")
 }
 
+func TestCompletionInstall(t *testing.T) {
+	tests := []struct {
+		name  string
+		shell string
+		path  string
+	}{
+		{name: "Bash", shell: "bash", path: ".local/share/bash-completion/completions/nsv"},
+		{name: "Zsh", shell: "zsh", path: ".zfunc/_nsv"},
+		{name: "Fish", shell: "fish", path: ".config/fish/completions/nsv.fish"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("XDG_DATA_HOME", "")
+			t.Setenv("XDG_CONFIG_HOME", "")
+
+			var buf bytes.Buffer
+			root := newRootCmd()
+			root.SetArgs([]string{"completion", "install", tt.shell})
+
+			err := Execute(root, WithStdout(&buf), WithCompletionCommand(WithCompletionInstall()))
+			require.NoError(t, err)
+
+			path := filepath.Join(home, tt.path)
+			assert.FileExists(t, path)
+			assert.Equal(t, "installed "+tt.shell+" completion to "+path+"\n", buf.String())
+
+			buf.Reset()
+			root = newRootCmd()
+			root.SetArgs([]string{"completion", "uninstall", tt.shell})
+
+			err = Execute(root, WithStdout(&buf), WithCompletionCommand(WithCompletionInstall()))
+			require.NoError(t, err)
+
+			assert.NoFileExists(t, path)
+			assert.Equal(t, "removed "+tt.shell+" completion from "+path+"\n", buf.String())
+		})
+	}
+}
+
+func TestCompletionInstallRespectsXDG(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dataHome)
+
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "install", "bash"})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}), WithCompletionCommand(WithCompletionInstall()))
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(dataHome, "bash-completion", "completions", "nsv"))
+}
+
+func TestCompletionInstallRejectsUnconfiguredShell(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "install", "powershell"})
+
+	err := Execute(root, WithStdout(&buf), WithStderr(&buf), WithCompletionCommand(WithCompletionInstall()))
+	require.EqualError(t, err, "unsupported shell: powershell")
+}
+
+func TestCompletionInstallUnsupportedShell(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var buf bytes.Buffer
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "install", "powershell"})
+
+	err := Execute(root, WithStdout(&buf), WithStderr(&buf), WithCompletionCommand(
+		WithExtraShells(ShellPowerShell),
+		WithCompletionInstall(),
+	))
+	require.EqualError(t, err, "completion install is not supported for shell: powershell")
+}
+
+func TestCompletionUninstallNotInstalled(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	var buf bytes.Buffer
+	root := newRootCmd()
+	root.SetArgs([]string{"completion", "uninstall", "fish"})
+
+	err := Execute(root, WithStdout(&buf), WithStderr(&buf), WithCompletionCommand(WithCompletionInstall()))
+	require.EqualError(t, err, "no fish completion installed at "+filepath.Join(home, ".config/fish/completions/nsv.fish"))
+}
+
 func TestDefaultShells(t *testing.T) {
 	shells := DefaultShells()
 	assert.Equal(t, []Shell{ShellBash, ShellZsh, ShellFish}, shells)
@@ -178,6 +560,36 @@ func TestCompleterValuesDescribed(t *testing.T) {
 	assert.NotNil(t, action)
 }
 
+func TestCompleterValuesFunc(t *testing.T) {
+	var calls int
+	completer := ValuesFunc(func() []string {
+		calls++
+		return []string{"dev", "prod"}
+	})
+	action := completer.toAction()
+	require.NotNil(t, action)
+	assert.Equal(t, 0, calls)
+
+	values := invokedValues(action.Invoke(carapace.NewContext()))
+	assert.Equal(t, 1, calls)
+	assert.ElementsMatch(t, []string{"dev", "prod"}, values)
+}
+
+func TestCompleterValuesDescribedFunc(t *testing.T) {
+	var calls int
+	completer := ValuesDescribedFunc(func() []string {
+		calls++
+		return []string{"dev", "Development", "prod", "Production"}
+	})
+	action := completer.toAction()
+	require.NotNil(t, action)
+	assert.Equal(t, 0, calls)
+
+	values := invokedValues(action.Invoke(carapace.NewContext()))
+	assert.Equal(t, 1, calls)
+	assert.ElementsMatch(t, []string{"dev", "prod"}, values)
+}
+
 func TestCompleterExecutables(t *testing.T) {
 	completer := Executables()
 	action := completer.toAction()
@@ -198,6 +610,182 @@ func TestCompleterActionFunc(t *testing.T) {
 	assert.NotNil(t, action)
 }
 
+func TestCompleterCache(t *testing.T) {
+	completer := Cache(time.Minute, ActionFunc(func() carapace.Action {
+		return carapace.ActionValues("cached1", "cached2")
+	}))
+	action := completer.toAction()
+	assert.NotNil(t, action)
+}
+
+func TestCompleterCacheKeyedByCallSite(t *testing.T) {
+	first := Cache(time.Minute, Values("one")).(cacheCompleter)
+	second := Cache(time.Minute, Values("two")).(cacheCompleter)
+	assert.NotEqual(t, first.key, second.key)
+}
+
+func TestCompleterNetworking(t *testing.T) {
+	tests := []struct {
+		name      string
+		completer Completer
+	}{
+		{name: "Hostnames", completer: Hostnames()},
+		{name: "NetInterfaces", completer: NetInterfaces()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := tt.completer.toAction()
+			assert.NotNil(t, action)
+		})
+	}
+}
+
+func TestCompleterHostnames(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	sshDir := filepath.Join(home, ".ssh")
+	require.NoError(t, os.MkdirAll(sshDir, 0o700))
+
+	knownHosts := `github.com ssh-ed25519 AAAA
+gitlab.com,172.65.251.78 ecdsa-sha2-nistp256 AAAA
+[git.internal]:2222 ssh-rsa AAAA
+|1|hashed|entry= ssh-rsa AAAA
+@cert-authority *.example.com ssh-rsa AAAA
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sshDir, "known_hosts"), []byte(knownHosts), 0o600))
+
+	config := `Host bastion
+    HostName 10.0.0.1
+
+Host *.internal
+    User admin
+`
+	require.NoError(t, os.WriteFile(filepath.Join(sshDir, "config"), []byte(config), 0o600))
+
+	values := invokedValues(Hostnames().toAction().Invoke(carapace.NewContext()))
+	assert.ElementsMatch(t, []string{"github.com", "gitlab.com", "172.65.251.78", "git.internal", "bastion"}, values)
+}
+
+func TestCompleterGit(t *testing.T) {
+	tests := []struct {
+		name      string
+		completer Completer
+	}{
+		{name: "GitBranches", completer: GitBranches()},
+		{name: "GitTags", completer: GitTags()},
+		{name: "GitRemotes", completer: GitRemotes()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action := tt.completer.toAction()
+			assert.NotNil(t, action)
+		})
+	}
+}
+
+func TestCompleterGitInRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=cli", "-c", "user.email=cli@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "--initial-branch", "main")
+	git("commit", "--allow-empty", "-m", "initial commit")
+	git("branch", "feature")
+	git("tag", "v0.1.0")
+	git("remote", "add", "origin", "https://example.com/repo.git")
+
+	ctx := carapace.NewContext()
+	assert.ElementsMatch(t, []string{"main", "feature"}, invokedValues(GitBranches().toAction().Invoke(ctx)))
+	assert.ElementsMatch(t, []string{"v0.1.0"}, invokedValues(GitTags().toAction().Invoke(ctx)))
+	assert.ElementsMatch(t, []string{"origin"}, invokedValues(GitRemotes().toAction().Invoke(ctx)))
+}
+
+func TestCompleterGitOutsideRepository(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	values := invokedValues(GitBranches().toAction().Invoke(carapace.NewContext()))
+	assert.Empty(t, values)
+}
+
+func TestCompleterFilter(t *testing.T) {
+	completer := Filter(Values("a", "b", "c"), "a")
+	action := completer.toAction()
+	require.NotNil(t, action)
+
+	values := invokedValues(action.Invoke(carapace.NewContext()))
+	assert.ElementsMatch(t, []string{"b", "c"}, values)
+}
+
+func TestCompleterFilterFunc(t *testing.T) {
+	completer := FilterFunc(Values("main", "dependabot/go", "feature"), func(v string) bool {
+		return strings.HasPrefix(v, "dependabot/")
+	})
+	action := completer.toAction()
+	require.NotNil(t, action)
+
+	values := invokedValues(action.Invoke(carapace.NewContext()))
+	assert.ElementsMatch(t, []string{"main", "feature"}, values)
+}
+
+func TestCompleterMerge(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.json"), nil, 0o644))
+	t.Chdir(dir)
+
+	completer := Merge(Values("-"), Files(".json"))
+	action := completer.toAction()
+	require.NotNil(t, action)
+
+	values := invokedValues(action.Invoke(carapace.NewContext()))
+	assert.ElementsMatch(t, []string{"-", "data.json"}, values)
+}
+
+func TestCompleterMessage(t *testing.T) {
+	completer := Message("run %s login to list projects", "nsv")
+	action := completer.toAction()
+	require.NotNil(t, action)
+
+	data, err := action.Invoke(carapace.NewContext()).MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "run nsv login to list projects")
+}
+
+func TestCompleterFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		inner    Completer
+		expected []string
+	}{
+		{name: "InnerHasValues", inner: Values("main", "develop"), expected: []string{"main", "develop"}},
+		{name: "InnerIsEmpty", inner: None(), expected: []string{"fallback"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completer := Fallback(tt.inner, Values("fallback"))
+			action := completer.toAction()
+			require.NotNil(t, action)
+
+			values := invokedValues(action.Invoke(carapace.NewContext()))
+			assert.ElementsMatch(t, tt.expected, values)
+		})
+	}
+}
+
 func TestCompleteFlag(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -231,6 +819,30 @@ func TestCompletePositional(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCompletePositionalRange(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Test command",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.SetArgs([]string{"--help"})
+
+	opts := defaultCompletionOptions()
+	CompletePositionalRange(1, 3, Directories())(opts)
+
+	assert.Len(t, opts.positional, 3)
+	for pos := 1; pos <= 3; pos++ {
+		assert.Contains(t, opts.positional, pos)
+	}
+
+	err := Execute(cmd, WithStdout(&buf), WithCompletionCommand(
+		CompletePositionalRange(1, 3, Directories()),
+	))
+	require.NoError(t, err)
+}
+
 func TestCompleteSubcommand(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -256,6 +868,144 @@ func TestCompleteSubcommand(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// completeArgs drives carapace's completion machinery for the given command
+// line and returns the candidate values.
+func completeArgs(t *testing.T, root *cobra.Command, opts []CompletionOption, args ...string) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	root.SetArgs(append([]string{"_carapace", "export", ""}, args...))
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(opts...))
+	require.NoError(t, err)
+
+	var export struct {
+		Values []struct {
+			Value string `json:"value"`
+		} `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &export), buf.String())
+
+	values := make([]string, len(export.Values))
+	for i, v := range export.Values {
+		values[i] = v.Value
+	}
+	return values
+}
+
+// completeDescriptions is like completeArgs, but returns the description of
+// each completion value keyed by the value.
+func completeDescriptions(t *testing.T, root *cobra.Command, opts []CompletionOption, args ...string) map[string]string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	root.SetArgs(append([]string{"_carapace", "export", ""}, args...))
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(opts...))
+	require.NoError(t, err)
+
+	var export struct {
+		Values []struct {
+			Value       string `json:"value"`
+			Description string `json:"description"`
+		} `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &export), buf.String())
+
+	descriptions := make(map[string]string, len(export.Values))
+	for _, v := range export.Values {
+		descriptions[v.Value] = v.Description
+	}
+	return descriptions
+}
+
+func TestWithCompletions(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.Flags().String("format", "", "output format")
+	root.SetArgs([]string{"_carapace", "export", "", "--format", ""})
+
+	err := Execute(root, WithStdout(&buf), WithCompletions(
+		CompleteFlag("format", Values("json", "yaml")),
+	))
+	require.NoError(t, err)
+
+	var export struct {
+		Values []struct {
+			Value string `json:"value"`
+		} `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &export), buf.String())
+	require.Len(t, export.Values, 2)
+	assert.Equal(t, "json", export.Values[0].Value)
+	assert.Equal(t, "yaml", export.Values[1].Value)
+
+	for _, c := range root.Commands() {
+		assert.NotEqual(t, "completion", c.Name())
+	}
+}
+
+func TestCompleteNestedSubcommand(t *testing.T) {
+	root := &cobra.Command{Use: "app"}
+	remote := &cobra.Command{Use: "remote", Short: "Manage remotes"}
+	add := &cobra.Command{
+		Use:   "add",
+		Short: "Add a remote",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	add.Flags().String("protocol", "", "transfer protocol")
+	remote.AddCommand(add)
+	root.AddCommand(remote)
+
+	values := completeArgs(t, root, []CompletionOption{
+		CompleteSubcommand("remote",
+			CompleteSubcommand("add",
+				CompleteFlag("protocol", Values("https", "ssh")),
+			),
+		),
+	}, "remote", "add", "--protocol", "")
+
+	assert.ElementsMatch(t, []string{"https", "ssh"}, values)
+}
+
+func TestCompleteFlagFunc(t *testing.T) {
+	tests := []struct {
+		name     string
+		cloud    string
+		expected []string
+	}{
+		{name: "AWS", cloud: "aws", expected: []string{"eu-west-1", "us-east-1"}},
+		{name: "GCP", cloud: "gcp", expected: []string{"europe-west1", "us-central1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := &cobra.Command{Use: "app"}
+			deploy := &cobra.Command{
+				Use:   "deploy <CLOUD>",
+				Short: "Deploy to a cloud",
+				Run:   func(_ *cobra.Command, _ []string) {},
+			}
+			deploy.Flags().String("region", "", "region to deploy to")
+			root.AddCommand(deploy)
+
+			values := completeArgs(t, root, []CompletionOption{
+				CompleteSubcommand("deploy",
+					CompleteFlagFunc("region", func(c carapace.Context) carapace.Action {
+						if len(c.Args) > 0 && c.Args[0] == "aws" {
+							return carapace.ActionValues("eu-west-1", "us-east-1")
+						}
+						return carapace.ActionValues("europe-west1", "us-central1")
+					}),
+				),
+			}, "deploy", tt.cloud, "--region", "")
+
+			assert.ElementsMatch(t, tt.expected, values)
+		})
+	}
+}
+
 func TestInferFlagCompletionsForEnum(t *testing.T) {
 	cmd := &cobra.Command{
 		Use: "test",
@@ -269,6 +1019,123 @@ func TestInferFlagCompletionsForEnum(t *testing.T) {
 	assert.Contains(t, actions, "log-level")
 }
 
+func TestInferFlagCompletionsForEnumDescriptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   *EnumValue[string]
+		expected map[string]string
+	}{
+		{
+			name:   "WithHelp",
+			format: Enum("json", "json", "yaml").WithHelp("JavaScript Object Notation", "YAML Ain't Markup Language"),
+			expected: map[string]string{
+				"json": "JavaScript Object Notation",
+				"yaml": "YAML Ain't Markup Language",
+			},
+		},
+		{
+			name:     "WithoutHelp",
+			format:   Enum("json", "json", "yaml"),
+			expected: map[string]string{"json": "", "yaml": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRootCmd()
+			root.Flags().Var(tt.format, "format", "output format")
+
+			descriptions := completeDescriptions(t, root, nil, "--format", "")
+			assert.Equal(t, tt.expected, descriptions)
+		})
+	}
+}
+
+func TestCompleteFlagNamesExcludesConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		excluded string
+	}{
+		{name: "Flag", args: []string{"--json", "--"}, excluded: "--template"},
+		{name: "ConflictingFlag", args: []string{"--template", "x", "--"}, excluded: "--json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRootCmd()
+			root.Flags().Bool("json", false, "output as JSON")
+			root.Flags().String("template", "", "output using a go template")
+			root.Flags().Bool("verbose", false, "verbose output")
+			MarkFlagConflicts(root.Flags().Lookup("json"), "template")
+
+			values := completeArgs(t, root, nil, tt.args...)
+			assert.Contains(t, values, "--verbose")
+			assert.NotContains(t, values, tt.excluded)
+		})
+	}
+}
+
+func TestCompletePersistentFlagOnSubcommand(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644))
+	t.Chdir(dir)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "Root", args: []string{"--config", ""}},
+		{name: "Subcommand", args: []string{"tag", "--config", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRootCmd()
+			root.PersistentFlags().String("config", "", "path to the config file")
+			root.AddCommand(newTagCmd())
+
+			values := completeArgs(t, root, []CompletionOption{
+				CompleteFlag("config", Files(".yaml")),
+			}, tt.args...)
+
+			assert.Equal(t, []string{"config.yaml"}, values)
+		})
+	}
+}
+
+func TestInferPersistentEnumFlagOnSubcommand(t *testing.T) {
+	root := newRootCmd()
+	root.AddCommand(newTagCmd())
+
+	values := completeArgs(t, root, nil, "tag", "--log-level", "")
+	assert.ElementsMatch(t, []string{"debug", "info", "warn", "error"}, values)
+}
+
+func TestCompletionDebug(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.PersistentFlags().String("config", "", "path to the config file")
+	root.AddCommand(newNextCmd(), newTagCmd())
+	root.SetArgs([]string{"completion", "_debug"})
+
+	err := Execute(root, WithStdout(&buf), WithCompletionCommand(
+		CompleteFlag("config", Files(".yaml", ".yml")),
+		CompleteSubcommand("next",
+			CompleteFlag("format", Values("v{{.Version}}", "{{.Version}}")),
+			CompletePositionalAny(Directories()),
+		),
+		CompleteSubcommand("tag",
+			CompletePositional(0, Cache(time.Minute, GitTags())),
+		),
+	))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "completion_debug.golden")
+}
+
 func TestCompletionNotShownOnSubcommandHelp(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// WithConfigFile loads flag values from a YAML, JSON or TOML config file. A
+// value is only applied to a flag that was neither provided on the command
+// line nor resolved from a bound environment variable, giving the precedence:
+//
+//  1. Explicit flag value on command line
+//  2. Environment variable
+//  3. Config file
+//  4. Flag default value
+//
+// Top-level keys map to flag names, written with dashes (log-level),
+// underscores (log_level) or in camel case (logLevel). Keys that do not match a
+// flag are ignored. Lists are applied to slice flags and tables to map flags.
+//
+// The format is one of "yaml", "json" or "toml". If empty, it is inferred
+// from the file extension. A missing config file is ignored.
+//
+//	# config.yaml
+//	log-level: debug
+//	labels:
+//	  - bug
+//	  - feature
+//
+//	cli.Execute(root, cli.WithConfigFile("config.yaml", "yaml"))
+func WithConfigFile(path, format string) Option {
+	return func(o *options) {
+		o.configFile = path
+		o.configFormat = format
+	}
+}
+
+func loadConfigFile(path, format string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("loading config file: %w", err)
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+
+	values := map[string]any{}
+	switch strings.ToLower(format) {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &values)
+	case "json":
+		err = json.Unmarshal(data, &values)
+	case "toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return values, nil
+}
+
+// addConfigFile applies config values to the flags of the executed command
+// once environment variables have been resolved.
+func addConfigFile(cmd *cobra.Command, path string, values map[string]any) {
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		var applyErr error
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if applyErr != nil {
+				return
+			}
+			err := applyConfigToFlag(f, values)
+			switch {
+			case err == nil:
+			case IsFlagSecret(f):
+				applyErr = fmt.Errorf("invalid value for --%s from config file %s", f.Name, path)
+			default:
+				applyErr = fmt.Errorf("invalid value for --%s from config file %s: %w", f.Name, path, err)
+			}
+		})
+		return applyErr
+	})
+}
+
+func applyConfigToFlag(flag *pflag.Flag, values map[string]any) error {
+	if flag.Changed {
+		return nil
+	}
+	if envVar := GetEnvVar(flag); envVar != "" && os.Getenv(envVar) != "" {
+		return nil
+	}
+
+	value, ok := configValue(values, flag.Name)
+	if !ok || value == nil {
+		return nil
+	}
+
+	var items []string
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			items = append(items, configString(item))
+		}
+	case map[string]any:
+		for key, item := range v {
+			items = append(items, key+"="+configString(item))
+		}
+		slices.Sort(items)
+	default:
+		return flag.Value.Set(configString(v))
+	}
+
+	if sv, ok := flag.Value.(pflag.SliceValue); ok {
+		return sv.Replace(items)
+	}
+	return flag.Value.Set(strings.Join(items, ","))
+}
+
+func configValue(values map[string]any, name string) (any, bool) {
+	for _, key := range []string{name, strings.ReplaceAll(name, "-", "_"), camelCase(name)} {
+		if value, ok := values[key]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+func camelCase(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func configString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		// JSON decodes every number as a float64, which fmt would print in
+		// exponent form when large
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestWithConfigFilePrecedence(t *testing.T) {
+	t.Setenv("TEST_FLAG", "env")
+	t.Setenv("TEST_ENV", "env")
+
+	path := writeConfigFile(t, "config.yaml", `flag: config
+env: config
+config: config
+`)
+
+	var buf bytes.Buffer
+	var flag, env, config, def string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(&flag, "flag", "default", "set on the command line")
+	cmd.Flags().StringVar(&env, "env", "default", "set from the environment")
+	cmd.Flags().StringVar(&config, "config", "default", "set from the config file")
+	cmd.Flags().StringVar(&def, "def", "default", "left as the default")
+	BindEnv(cmd.Flags().Lookup("flag"), "TEST_FLAG")
+	BindEnv(cmd.Flags().Lookup("env"), "TEST_ENV")
+	BindEnv(cmd.Flags().Lookup("config"), "TEST_CONFIG")
+
+	err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, "yaml"), WithArgs("--flag", "flag"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "flag", flag)
+	assert.Equal(t, "env", env)
+	assert.Equal(t, "config", config)
+	assert.Equal(t, "default", def)
+}
+
+func TestWithConfigFileFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		format string
+		data   string
+	}{
+		{
+			name:   "YAML",
+			file:   "config.yaml",
+			format: "yaml",
+			data: `log-level: debug
+port: 9090
+verbose: true
+labels:
+  - bug
+  - feature
+headers:
+  Accept: application/json
+`,
+		},
+		{
+			name:   "JSON",
+			file:   "config.json",
+			format: "json",
+			data: `{
+  "logLevel": "debug",
+  "port": 9090,
+  "verbose": true,
+  "labels": ["bug", "feature"],
+  "headers": {"Accept": "application/json"}
+}`,
+		},
+		{
+			name:   "TOML",
+			file:   "config.toml",
+			format: "toml",
+			data: `# config
+log_level = "debug"
+port = 9090
+verbose = true
+labels = ["bug", "feature"]
+`,
+		},
+		{
+			name: "InferredFromExtension",
+			file: "config.yml",
+			data: `log_level: debug
+port: 9090
+verbose: true
+labels: [bug, feature]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.file, tt.data)
+
+			var buf bytes.Buffer
+			var logLevel string
+			var port int
+			var verbose bool
+			var labels []string
+
+			cmd := &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().StringVar(&logLevel, "log-level", "info", "logging verbosity")
+			cmd.Flags().IntVar(&port, "port", 8080, "port to listen on")
+			cmd.Flags().BoolVar(&verbose, "verbose", false, "verbose output")
+			cmd.Flags().StringSliceVar(&labels, "labels", []string{"default"}, "labels to apply")
+
+			err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, tt.format), WithArgs())
+			require.NoError(t, err)
+
+			assert.Equal(t, "debug", logLevel)
+			assert.Equal(t, 9090, port)
+			assert.True(t, verbose)
+			assert.Equal(t, []string{"bug", "feature"}, labels)
+		})
+	}
+}
+
+func TestWithConfigFileMapFlag(t *testing.T) {
+	path := writeConfigFile(t, "config.json", `{"headers": {"Accept": "application/json", "X-Trace": "1"}}`)
+
+	var buf bytes.Buffer
+	headers := StringMap()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(headers, "headers", "headers to send")
+
+	err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, "json"), WithArgs())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"Accept": "application/json", "X-Trace": "1"}, headers.Get())
+}
+
+func TestWithConfigFileInheritedFlag(t *testing.T) {
+	path := writeConfigFile(t, "config.yaml", "log-level: debug\n")
+
+	var buf bytes.Buffer
+	var logLevel string
+
+	root := &cobra.Command{Use: "test"}
+	root.PersistentFlags().StringVar(&logLevel, "log-level", "info", "logging verbosity")
+	root.AddCommand(&cobra.Command{
+		Use: "sub",
+		Run: func(_ *cobra.Command, _ []string) {},
+	})
+
+	err := Execute(root, WithStdout(&buf), WithConfigFile(path, "yaml"), WithArgs("sub"))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", logLevel)
+}
+
+func TestWithConfigFileMissingIgnored(t *testing.T) {
+	var buf bytes.Buffer
+	var logLevel string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(&logLevel, "log-level", "info", "logging verbosity")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, "yaml"), WithArgs())
+	require.NoError(t, err)
+	assert.Equal(t, "info", logLevel)
+}
+
+func TestWithConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   string
+		format string
+		data   string
+		err    string
+	}{
+		{
+			name:   "UnsupportedFormat",
+			file:   "config.ini",
+			format: "",
+			data:   "port=9090",
+			err:    `unsupported config file format: "ini"`,
+		},
+		{
+			name:   "InvalidValue",
+			file:   "config.yaml",
+			format: "yaml",
+			data:   "port: abc",
+			err:    `invalid value for --port from config file {path}: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			name:   "InvalidTOML",
+			file:   "config.toml",
+			format: "toml",
+			data:   "port = 9090\nport = 8080",
+			err:    "parsing config file {path}: toml: line 2 (last key \"port\"): Key 'port' has already been defined.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfigFile(t, tt.file, tt.data)
+
+			var buf bytes.Buffer
+
+			cmd := &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().Int("port", 8080, "port to listen on")
+
+			err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, tt.format), WithArgs())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), strings.ReplaceAll(tt.err, "{path}", path))
+		})
+	}
+}
+
+func TestWithConfigFileTOML(t *testing.T) {
+	path := writeConfigFile(t, "config.toml", `# config
+log-level = 'C:\logs\debug' # literal string
+port = 9_090
+labels = [
+  "bug",
+  "feature",
+]
+env.CI = "true"
+
+[headers]
+Accept = "application/json"
+X-Trace = 1
+`)
+
+	var buf bytes.Buffer
+	var logLevel string
+	var port int
+	var labels []string
+	env := StringMap()
+	headers := StringMap()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(&logLevel, "log-level", "info", "logging verbosity")
+	cmd.Flags().IntVar(&port, "port", 8080, "port to listen on")
+	cmd.Flags().StringSliceVar(&labels, "labels", nil, "labels to apply")
+	cmd.Flags().Var(env, "env", "environment variables to set")
+	cmd.Flags().Var(headers, "headers", "headers to send")
+
+	err := Execute(cmd, WithStdout(&buf), WithConfigFile(path, "toml"), WithArgs())
+	require.NoError(t, err)
+
+	assert.Equal(t, `C:\logs\debug`, logLevel)
+	assert.Equal(t, 9090, port)
+	assert.Equal(t, []string{"bug", "feature"}, labels)
+	assert.Equal(t, map[string]string{"CI": "true"}, env.Get())
+	assert.Equal(t, map[string]string{"Accept": "application/json", "X-Trace": "1"}, headers.Get())
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+const confirmFlag = "yes"
+
+// inputIsTerminal reports whether r is attached to a terminal. It is an
+// indirection to support testing prompts without a terminal.
+var inputIsTerminal = func(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && isTerminal(f)
+}
+
+type themeKey struct{}
+
+// withTheme stores theme within ctx, making it available to helpers that
+// only receive the executed command.
+func withTheme(ctx context.Context, theme Theme) context.Context {
+	return context.WithValue(ctx, themeKey{}, theme)
+}
+
+func themeFrom(ctx context.Context) Theme {
+	if ctx != nil {
+		if theme, ok := ctx.Value(themeKey{}).(Theme); ok {
+			return theme
+		}
+	}
+	return DefaultTheme()
+}
+
+// WithConfirmFlag adds a -y/--yes flag to the root command, inherited by
+// every subcommand, that answers yes to any prompt raised by [Confirm].
+//
+//	cli.Execute(root, cli.WithConfirmFlag())
+func WithConfirmFlag() Option {
+	return func(o *options) {
+		o.confirmFlag = true
+	}
+}
+
+func addConfirmFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolP(confirmFlag, "y", false, "answer yes to every confirmation prompt")
+}
+
+// Confirm asks the user to confirm an action, writing prompt to stderr and
+// reading the answer from stdin. Only y or yes confirms the action, with any
+// other answer, including none, declining it. If the flag added by
+// [WithConfirmFlag] is provided, the prompt is skipped and the action is
+// confirmed. Otherwise, the action is declined without prompting if stdin is
+// not a terminal.
+//
+//	if ok, err := cli.Confirm(cmd, "Delete every release?"); err != nil || !ok {
+//	    return err
+//	}
+func Confirm(cmd *cobra.Command, prompt string) (bool, error) {
+	if yes, err := cmd.Flags().GetBool(confirmFlag); err == nil && yes {
+		return true, nil
+	}
+
+	in := cmd.InOrStdin()
+	if !inputIsTerminal(in) {
+		return false, nil
+	}
+
+	theme := themeFrom(cmd.Context())
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s %s ", theme.Description.Render(prompt), theme.FlagType.Render("[y/N]"))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("reading confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
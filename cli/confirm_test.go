@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stubInputIsTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+
+	original := inputIsTerminal
+	inputIsTerminal = func(io.Reader) bool { return terminal }
+	t.Cleanup(func() { inputIsTerminal = original })
+}
+
+func executeConfirm(t *testing.T, input string, args ...string) (bool, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	var confirmed bool
+
+	cmd := &cobra.Command{
+		Use: "test",
+		RunE: func(c *cobra.Command, _ []string) error {
+			var err error
+			confirmed, err = Confirm(c, "Delete every release?")
+			return err
+		},
+	}
+
+	err := Execute(cmd,
+		WithStdin(strings.NewReader(input)),
+		WithStdout(&stdout),
+		WithStderr(&stderr),
+		WithConfirmFlag(),
+		WithArgs(args...))
+	require.NoError(t, err)
+
+	return confirmed, stderr.String()
+}
+
+func TestConfirm(t *testing.T) {
+	stubInputIsTerminal(t, true)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "Yes", input: "y\n", expected: true},
+		{name: "YesWord", input: "Yes\n", expected: true},
+		{name: "No", input: "n\n", expected: false},
+		{name: "Empty", input: "\n", expected: false},
+		{name: "NoInput", input: "", expected: false},
+		{name: "Unrecognised", input: "sure\n", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			confirmed, stderr := executeConfirm(t, tt.input)
+			assert.Equal(t, tt.expected, confirmed)
+			assert.Equal(t, "Delete every release? [y/N] ", stderr)
+		})
+	}
+}
+
+func TestConfirmWithYesFlag(t *testing.T) {
+	stubInputIsTerminal(t, true)
+
+	confirmed, stderr := executeConfirm(t, "n\n", "--yes")
+	assert.True(t, confirmed)
+	assert.Empty(t, stderr)
+}
+
+func TestConfirmNotTerminal(t *testing.T) {
+	stubInputIsTerminal(t, false)
+
+	confirmed, stderr := executeConfirm(t, "y\n")
+	assert.False(t, confirmed)
+	assert.Empty(t, stderr)
+
+	confirmed, _ = executeConfirm(t, "", "-y")
+	assert.True(t, confirmed)
+}
+
+func TestConfirmStyled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	stubInputIsTerminal(t, true)
+
+	var stderr bytes.Buffer
+	theme := newStyledTheme()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		RunE: func(c *cobra.Command, _ []string) error {
+			_, err := Confirm(c, "Delete every release?")
+			return err
+		},
+	}
+
+	err := Execute(cmd,
+		WithStdin(strings.NewReader("y\n")),
+		WithStdout(&bytes.Buffer{}),
+		WithStderr(&stderr),
+		WithTheme(theme),
+		WithArgs())
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr.String(), "\x1b[")
+	expected := theme.Description.Render("Delete every release?") + " " + theme.FlagType.Render("[y/N]") + " "
+	assert.Equal(t, expected, stderr.String())
+}
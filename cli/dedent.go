@@ -5,7 +5,19 @@ import (
 	"strings"
 )
 
+// tabWidth is the number of columns between tab stops when measuring the
+// indentation of a line.
+const tabWidth = 4
+
 func dedent(s string) string {
+	return strings.TrimSpace(unindent(s))
+}
+
+// unindent removes the indentation common to every non-blank line of s,
+// leaving any further indentation intact. Indentation is measured in visual
+// columns, so lines indented with a mix of tabs and spaces line up as they
+// would in an editor.
+func unindent(s string) string {
 	lines := strings.Split(s, "\n")
 
 	minIndent := math.MaxInt
@@ -13,8 +25,7 @@ func dedent(s string) string {
 		if len(strings.TrimSpace(line)) == 0 {
 			continue
 		}
-		indent := len(line) - len(strings.TrimLeft(line, " \t"))
-		if indent < minIndent {
+		if indent := indentWidth(line); indent < minIndent {
 			minIndent = indent
 		}
 	}
@@ -24,10 +35,48 @@ func dedent(s string) string {
 	}
 
 	for i, line := range lines {
-		if len(line) >= minIndent {
-			lines[i] = line[minIndent:]
+		lines[i] = trimIndent(line, minIndent)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func indentWidth(line string) int {
+	col := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			col++
+		case '\t':
+			col += tabWidth - col%tabWidth
+		default:
+			return col
 		}
 	}
+	return col
+}
+
+// trimIndent removes n columns of indentation from line. A tab that spans
+// beyond n columns is replaced by the spaces it would have left behind.
+func trimIndent(line string, n int) string {
+	col := 0
+	for i, r := range line {
+		if col >= n {
+			return line[i:]
+		}
 
-	return strings.TrimSpace(strings.Join(lines, "\n"))
+		switch r {
+		case ' ':
+			col++
+		case '\t':
+			col += tabWidth - col%tabWidth
+		default:
+			return line[i:]
+		}
+
+		if col > n {
+			return strings.Repeat(" ", col-n) + line[i+1:]
+		}
+	}
+	return ""
 }
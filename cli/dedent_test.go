@@ -43,6 +43,26 @@ func TestDedent(t *testing.T) {
 			input:    "\t\tline one\n\t\tline two",
 			expected: "line one\nline two",
 		},
+		{
+			name:     "WithTabAndSpaces",
+			input:    "\tline one\n    line two",
+			expected: "line one\nline two",
+		},
+		{
+			name:     "WithTabAndSpacesNested",
+			input:    "  \tline one\n        line two\n\t\tline three",
+			expected: "line one\n    line two\n\tline three",
+		},
+		{
+			name:     "WithTabWiderThanIndentation",
+			input:    "  line one\n\tline two",
+			expected: "line one\n  line two",
+		},
+		{
+			name:     "WithNestedTabs",
+			input:    "\tline one\n\t\tline two",
+			expected: "line one\n\tline two",
+		},
 		{
 			name:     "WithNoIndentation",
 			input:    "line one\nline two",
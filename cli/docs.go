@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// DocsOption configures the generated Markdown documentation.
+type DocsOption func(*docsOptions)
+
+type docsOptions struct {
+	frontMatter string
+}
+
+// WithFrontMatter prepends front matter to every generated Markdown page,
+// rendered through a [text/template]. The template receives the command's
+// Name, its full Path (e.g. "nsv next") and its Short description.
+//
+//	cli.WithDocsCommand(
+//	    cli.WithFrontMatter("---\ntitle: {{.Path}}\ndescription: {{.Short}}\n---\n"),
+//	)
+func WithFrontMatter(tmpl string) DocsOption {
+	return func(o *docsOptions) {
+		o.frontMatter = tmpl
+	}
+}
+
+// WithDocsCommand adds a hidden "docs" command that writes a Markdown page for
+// every command in the tree, ready for publishing to a documentation site.
+// Pages are written to the directory given by --output-dir, which defaults
+// to docs, and are generated from the same metadata as the help output.
+//
+//	cli.Execute(root, cli.WithDocsCommand())
+//
+// Then generate the documentation with:
+//
+//	myapp docs --output-dir site/content/cli
+func WithDocsCommand(opts ...DocsOption) Option {
+	return func(o *options) {
+		o.docs = &docsOptions{}
+		for _, opt := range opts {
+			opt(o.docs)
+		}
+	}
+}
+
+// docsPage is the data passed to the front matter template.
+type docsPage struct {
+	Name  string
+	Path  string
+	Short string
+}
+
+func newDocsCommand(frontMatter *template.Template, enumLimit int) *cobra.Command {
+	var outputDir string
+
+	cmd := &cobra.Command{
+		Use:                   "docs",
+		Short:                 "Generate Markdown documentation for the CLI",
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Hidden:                true,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := os.MkdirAll(outputDir, 0o755); err != nil {
+				return fmt.Errorf("creating docs directory: %w", err)
+			}
+			return writeDocsPages(outputDir, cmd.Root(), frontMatter, enumLimit)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputDir, "output-dir", "docs", "write a Markdown page for every command to this directory")
+
+	return cmd
+}
+
+func writeDocsPages(dir string, cmd *cobra.Command, frontMatter *template.Template, enumLimit int) error {
+	page, err := renderDocsPage(cmd, frontMatter, enumLimit)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, commandPageName(cmd)+".md")
+	if err := os.WriteFile(path, []byte(page), 0o644); err != nil {
+		return fmt.Errorf("writing docs page: %w", err)
+	}
+
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		if err := writeDocsPages(dir, sub, frontMatter, enumLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderDocsPage renders a Markdown page for a command, sourcing its content
+// from the same metadata as the help output.
+func renderDocsPage(cmd *cobra.Command, frontMatter *template.Template, enumLimit int) (string, error) {
+	var b strings.Builder
+
+	if frontMatter != nil {
+		data := docsPage{Name: cmd.Name(), Path: cmd.CommandPath(), Short: cmd.Short}
+		if err := frontMatter.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("rendering front matter: %w", err)
+		}
+	}
+
+	fmt.Fprintf(&b, "# %s\n", cmd.CommandPath())
+
+	desc := cmd.Long
+	if desc == "" {
+		desc = cmd.Short
+	}
+	if desc != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(dedent(desc)))
+	}
+
+	b.WriteString("\n## USAGE\n\n")
+	fmt.Fprintf(&b, "```\n%s\n```\n", formatUsage(cmd, DefaultTheme()))
+
+	if hasSubCommands(cmd) {
+		b.WriteString("\n## COMMANDS\n\n")
+		for _, sub := range cmd.Commands() {
+			if sub.Hidden {
+				continue
+			}
+			fmt.Fprintf(&b, "- [%s](%s.md): %s\n", sub.Name(), commandPageName(sub), sub.Short)
+		}
+	}
+
+	if cmd.Example != "" {
+		b.WriteString("\n## EXAMPLES\n\n")
+		fmt.Fprintf(&b, "```sh\n%s\n```\n", strings.TrimSpace(dedent(cmd.Example)))
+	}
+
+	sections := pageFlagSections(cmd)
+	for _, section := range sections {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.name)
+		writeDocsFlags(&b, section.flags, enumLimit)
+	}
+
+	if bindings := pageEnvBindings(sections); len(bindings) > 0 {
+		b.WriteString("\n## ENVIRONMENT\n\n")
+		b.WriteString("| Variable | Flag |\n| --- | --- |\n")
+		for _, f := range bindings {
+			fmt.Fprintf(&b, "| `%s` | `--%s` |\n", GetEnvVar(f), f.Name)
+		}
+	}
+
+	if seeAlso := pageSeeAlso(cmd); len(seeAlso) > 0 {
+		b.WriteString("\n## SEE ALSO\n\n")
+		for _, c := range seeAlso {
+			fmt.Fprintf(&b, "- [%s](%s.md): %s\n", c.CommandPath(), commandPageName(c), c.Short)
+		}
+	}
+
+	return b.String(), nil
+}
+
+func writeDocsFlags(b *strings.Builder, flags []*pflag.Flag, enumLimit int) {
+	for _, f := range flags {
+		pf := newPageFlag(f, enumLimit)
+
+		flagStr := "--" + pf.name
+		if pf.shorthand != "" {
+			flagStr = "-" + pf.shorthand + ", " + flagStr
+		}
+		if pf.placeholder != "" {
+			flagStr += " <" + pf.placeholder + ">"
+		}
+
+		fmt.Fprintf(b, "- `%s`: %s", flagStr, pf.usage)
+		if pf.defValue != "" {
+			fmt.Fprintf(b, " (default: `%s`)", pf.defValue)
+		}
+		if pf.envVar != "" {
+			fmt.Fprintf(b, " [env: `%s`]", pf.envVar)
+		}
+		b.WriteString("\n")
+
+		for _, entry := range pf.values {
+			if entry.Help == "" {
+				fmt.Fprintf(b, "  - `%s`\n", entry.Name)
+				continue
+			}
+			fmt.Fprintf(b, "  - `%s`: %s\n", entry.Name, entry.Help)
+		}
+	}
+}
+
+// pageFlag is the unstyled content of a flag within a generated manpage or
+// Markdown page.
+type pageFlag struct {
+	name        string
+	shorthand   string
+	placeholder string
+	usage       string
+	defValue    string
+	envVar      string
+	values      []EnumOption
+}
+
+// newPageFlag resolves the content of a flag in the same way as the help
+// output. The placeholder is empty for a bool flag, as is the default if it
+// is not worth showing. Values are only listed for enums with help.
+func newPageFlag(f *pflag.Flag, enumLimit int) pageFlag {
+	pf := pageFlag{
+		name:      f.Name,
+		shorthand: f.Shorthand,
+		usage:     f.Usage,
+		envVar:    GetEnvVar(f),
+	}
+
+	if f.Value.Type() != "bool" {
+		pf.placeholder, _ = flagPlaceholder(f, enumLimit)
+	}
+	if defValue, valueType, ok := flagDefault(f); ok {
+		pf.defValue = formatDefaultValue(defValue, valueType, lipgloss.NewStyle())
+	}
+	if helper, ok := f.Value.(EnumHelper); ok && helper.HasHelp() {
+		pf.values = helper.HelpEntries()
+	}
+	return pf
+}
+
+// pageFlagSections returns the flags of a generated page in the same order as
+// the help output: ungrouped local flags, each flag group and then any
+// inherited flags. Sections are named by their heading.
+func pageFlagSections(cmd *cobra.Command) []flagGroup {
+	var sections []flagGroup
+	if cmd.HasAvailableLocalFlags() {
+		ungrouped, groups := collectFlagGroups(cmd.LocalFlags(), false)
+		if len(ungrouped) > 0 {
+			sections = append(sections, flagGroup{name: "FLAGS", flags: ungrouped})
+		}
+		for _, g := range groups {
+			sections = append(sections, flagGroup{name: strings.ToUpper(g.name), flags: g.flags})
+		}
+	}
+
+	if cmd.HasAvailableInheritedFlags() && cmd.Annotations["hideInheritedFlags"] != "true" {
+		sections = append(sections, flagGroup{name: "GLOBAL FLAGS", flags: visibleFlags(cmd.InheritedFlags(), false)})
+	}
+	return sections
+}
+
+// pageEnvBindings returns the flags within the sections of a generated page
+// that are bound to an environment variable.
+func pageEnvBindings(sections []flagGroup) []*pflag.Flag {
+	var bindings []*pflag.Flag
+	for _, section := range sections {
+		for _, f := range section.flags {
+			if GetEnvVar(f) != "" {
+				bindings = append(bindings, f)
+			}
+		}
+	}
+	return bindings
+}
+
+// pageSeeAlso returns the commands referenced within the SEE ALSO section of
+// a generated page, being its parent followed by any commands marked as
+// related. Hidden commands are skipped, as no page is generated for them.
+func pageSeeAlso(cmd *cobra.Command) []*cobra.Command {
+	var seeAlso []*cobra.Command
+	if cmd.HasParent() {
+		seeAlso = append(seeAlso, cmd.Parent())
+	}
+	for _, c := range relatedCommands(cmd) {
+		if !c.Hidden && !slices.Contains(seeAlso, c) {
+			seeAlso = append(seeAlso, c)
+		}
+	}
+	return seeAlso
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/golden"
+)
+
+func TestDocsCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	root := newRootCmd()
+	next := newNextCmd()
+	BindEnv(next.Flags().Lookup("format"), "NSV_FORMAT")
+	MarkRelated(next, "tag")
+	root.AddCommand(next, newTagCmd())
+	root.SetArgs([]string{"docs", "--output-dir", dir})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}), WithDocsCommand())
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"nsv.md", "nsv-next.md", "nsv-tag.md"}, names)
+
+	page, err := os.ReadFile(filepath.Join(dir, "nsv.md"))
+	require.NoError(t, err)
+	golden.Assert(t, string(page), "docs_root.golden")
+
+	page, err = os.ReadFile(filepath.Join(dir, "nsv-next.md"))
+	require.NoError(t, err)
+	golden.Assert(t, string(page), "docs_subcommand.golden")
+}
+
+func TestDocsCommandEnumPlaceholder(t *testing.T) {
+	dir := t.TempDir()
+
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Format the source code",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(Enum("go", "go", "rust", "python", "java"), "lang", "language to format")
+	cmd.Flags().Var(Enum("json", "json", "yaml").WithHelp("JavaScript Object Notation"), "format", "output format")
+	cmd.SetArgs([]string{"docs", "--output-dir", dir})
+
+	err := Execute(cmd, WithStdout(&bytes.Buffer{}), WithDocsCommand(), WithEnumLimit(2))
+	require.NoError(t, err)
+
+	page, err := os.ReadFile(filepath.Join(dir, "fmt.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "- `--lang <go|rust|...>`: language to format")
+	assert.Contains(t, string(page), "- `--format <string>`: output format")
+}
+
+func TestDocsCommandFrontMatter(t *testing.T) {
+	dir := t.TempDir()
+
+	root := newRootCmd()
+	root.AddCommand(newTagCmd())
+	root.SetArgs([]string{"docs", "--output-dir", dir})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}), WithDocsCommand(
+		WithFrontMatter("---\ntitle: {{.Path}}\nslug: {{.Name}}\n---\n\n"),
+	))
+	require.NoError(t, err)
+
+	page, err := os.ReadFile(filepath.Join(dir, "nsv-tag.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "---\ntitle: nsv tag\nslug: tag\n---\n\n# nsv tag\n")
+}
+
+func TestDocsCommandInvalidFrontMatter(t *testing.T) {
+	root := newRootCmd()
+	root.SetArgs([]string{"docs"})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}), WithDocsCommand(WithFrontMatter("{{.Path")))
+	require.ErrorContains(t, err, "invalid docs front matter template")
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// WithDotEnv loads environment variables from the given files before
+// environment variables bound to flags are resolved. Variables already set
+// within the environment are never overwritten, and earlier files take
+// precedence over later ones. If no paths are provided, a .env file in the
+// working directory is loaded if it exists. Explicitly provided files must
+// exist.
+//
+// Files contain KEY=value pairs, one per line. Values may be wrapped in single
+// or double quotes and lines starting with # are treated as comments:
+//
+//	# .env
+//	GITHUB_TOKEN=ghp_abc123
+//	LOG_LEVEL="debug"
+//
+//	cli.Execute(root, cli.WithDotEnv())
+func WithDotEnv(paths ...string) Option {
+	return func(o *options) {
+		o.dotenv = paths
+		o.dotenvRequired = len(paths) > 0
+		if len(paths) == 0 {
+			o.dotenv = []string{".env"}
+		}
+	}
+}
+
+func loadDotEnv(paths []string, required bool) error {
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && !required {
+				continue
+			}
+			return fmt.Errorf("loading env file: %w", err)
+		}
+
+		vars, err := parseDotEnv(string(data))
+		if err != nil {
+			return fmt.Errorf("parsing env file %s: %w", path, err)
+		}
+
+		for _, v := range vars {
+			if _, ok := os.LookupEnv(v.key); ok {
+				continue
+			}
+			if err := os.Setenv(v.key, v.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+type dotEnvVar struct {
+	key   string
+	value string
+}
+
+func parseDotEnv(data string) ([]dotEnvVar, error) {
+	var vars []dotEnvVar
+
+	n := 0
+	for line := range strings.Lines(data) {
+		n++
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(strings.TrimPrefix(key, "export "))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=value", n)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		vars = append(vars, dotEnvVar{key: key, value: value})
+	}
+
+	return vars, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch quote := value[0]; quote {
+	case '"', '\'':
+		end := strings.LastIndexByte(value, quote)
+		if end == 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		if quote == '\'' {
+			return value[1:end], nil
+		}
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value: %w", err)
+		}
+		return unquoted, nil
+	}
+
+	// Unquoted values may be followed by an inline comment
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value, nil
+}
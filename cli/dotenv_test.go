@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	data := `# comment
+export TOKEN=abc123
+LOG_LEVEL = debug # inline comment
+GREETING="hello\nworld"
+RAW='raw # value'
+EMPTY=
+URL=https://example.com/#anchor
+`
+
+	vars, err := parseDotEnv(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, []dotEnvVar{
+		{key: "TOKEN", value: "abc123"},
+		{key: "LOG_LEVEL", value: "debug"},
+		{key: "GREETING", value: "hello\nworld"},
+		{key: "RAW", value: "raw # value"},
+		{key: "EMPTY", value: ""},
+		{key: "URL", value: "https://example.com/#anchor"},
+	}, vars)
+}
+
+func TestParseDotEnvInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "MissingEquals", data: "TOKEN", err: "line 1: expected KEY=value"},
+		{name: "MissingKey", data: "\n=value", err: "line 2: expected KEY=value"},
+		{name: "UnterminatedQuote", data: `TOKEN="abc`, err: "line 1: unterminated quoted value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDotEnv(tt.data)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func newDotEnvCmd(val *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(val, "key", "default", "test flag")
+	BindEnv(cmd.Flags().Lookup("key"), "DOTENV_TEST_KEY")
+	cmd.SetArgs([]string{})
+	return cmd
+}
+
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	// Register a restore of the original value before unsetting
+	t.Setenv(key, "")
+	os.Unsetenv(key)
+}
+
+func TestWithDotEnv(t *testing.T) {
+	unsetEnv(t, "DOTENV_TEST_KEY")
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile(".env", []byte("DOTENV_TEST_KEY=from-file\n"), 0o644))
+
+	var val string
+	err := Execute(newDotEnvCmd(&val), WithStdout(&bytes.Buffer{}), WithDotEnv())
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", val)
+}
+
+func TestWithDotEnvEnvironmentWins(t *testing.T) {
+	t.Setenv("DOTENV_TEST_KEY", "from-env")
+	path := filepath.Join(t.TempDir(), "app.env")
+	require.NoError(t, os.WriteFile(path, []byte("DOTENV_TEST_KEY=from-file\n"), 0o644))
+
+	var val string
+	err := Execute(newDotEnvCmd(&val), WithStdout(&bytes.Buffer{}), WithDotEnv(path))
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", val)
+}
+
+func TestWithDotEnvEarlierFileWins(t *testing.T) {
+	unsetEnv(t, "DOTENV_TEST_KEY")
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.env")
+	second := filepath.Join(dir, "second.env")
+	require.NoError(t, os.WriteFile(first, []byte("DOTENV_TEST_KEY=first\n"), 0o644))
+	require.NoError(t, os.WriteFile(second, []byte("DOTENV_TEST_KEY=second\n"), 0o644))
+
+	var val string
+	err := Execute(newDotEnvCmd(&val), WithStdout(&bytes.Buffer{}), WithDotEnv(first, second))
+	require.NoError(t, err)
+	assert.Equal(t, "first", val)
+}
+
+func TestWithDotEnvMissingDefaultIgnored(t *testing.T) {
+	unsetEnv(t, "DOTENV_TEST_KEY")
+	t.Chdir(t.TempDir())
+
+	var val string
+	err := Execute(newDotEnvCmd(&val), WithStdout(&bytes.Buffer{}), WithDotEnv())
+	require.NoError(t, err)
+	assert.Equal(t, "default", val)
+}
+
+func TestWithDotEnvMissingExplicitFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.env")
+
+	var val string
+	err := Execute(newDotEnvCmd(&val), WithStdout(&bytes.Buffer{}), WithDotEnv(path))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -26,20 +29,30 @@ type EnumHelper interface {
 	BaseType() string
 }
 
+// EnumDefaultNamer is optionally implemented by an [EnumHelper] to name its
+// default value, which help shows in place of the flag's raw default.
+type EnumDefaultNamer interface {
+	DefaultName() string
+}
+
 // EnumValue implements pflag.Value for type-safe enumeration flags.
 type EnumValue[T Enumerable] struct {
-	value    T
-	names    map[T]string
-	values   map[string]T
-	allowed  []string
-	help     map[string]string
-	baseType string
+	value           T
+	defaultValue    T
+	names           map[T]string
+	values          map[string]T
+	allowed         []string
+	help            map[string]string
+	baseType        string
+	caseInsensitive bool
+	aliases         map[string]T
 }
 
 // Enum creates a new type-safe enum flag. The first argument is the default
 // value, followed by all allowed values. For string-based enums, the string
 // value is used as the display name. For integer-based enums, the numeric
-// value is used as the display name.
+// value is used as the display name. Allowed values are not validated, use
+// [NewEnum] or [MustEnum] to reject duplicates or an empty set.
 //
 // String enum example:
 //
@@ -84,12 +97,108 @@ func Enum[T Enumerable](def T, allowed ...T) *EnumValue[T] {
 	}
 
 	return &EnumValue[T]{
-		value:    def,
-		names:    names,
-		values:   values,
-		allowed:  orderedNames,
-		baseType: baseType,
+		value:        def,
+		defaultValue: def,
+		names:        names,
+		values:       values,
+		allowed:      orderedNames,
+		baseType:     baseType,
+	}
+}
+
+// EnumEntry declares an allowed enum value together with its display name
+// and help text, for use with [EnumFrom].
+type EnumEntry[T Enumerable] struct {
+	// Value is the typed value the entry resolves to.
+	Value T
+
+	// Name is the display name of the value, used in help output, completion
+	// and when parsing. If empty, the value itself is displayed, as with
+	// [Enum].
+	Name string
+
+	// Help is an optional description of the value.
+	Help string
+}
+
+// EnumFrom creates a new type-safe enum flag from fully declared entries,
+// keeping each value alongside its name and help text. This avoids aligning
+// the arguments of [EnumValue.WithNames] and [EnumValue.WithHelp] with the
+// allowed values by position. Use [Enum] when values need neither.
+//
+// EnumFrom panics if no entries are given, or if two entries share the same
+// value or name, as these indicate a programming error.
+//
+//	trust := cli.EnumFrom([]cli.EnumEntry[TrustLevel]{
+//	    {Value: TrustUnknown, Name: "unknown", Help: "trust has not been assigned"},
+//	    {Value: TrustNever, Name: "never", Help: "the key should never be trusted"},
+//	    {Value: TrustMarginal, Name: "marginal", Help: "the key is marginally trusted"},
+//	}, TrustUnknown)
+//	cmd.Flags().Var(trust, "trust-level", "GPG trust level")
+func EnumFrom[T Enumerable](entries []EnumEntry[T], def T) *EnumValue[T] {
+	allowed := make([]T, len(entries))
+	for i, entry := range entries {
+		allowed[i] = entry.Value
+	}
+	e := MustEnum(def, allowed...)
+
+	// Names are applied in a single pass, unlike WithNames, so an entry may
+	// be named after the value of another entry
+	e.values = make(map[string]T, len(entries))
+	e.help = make(map[string]string, len(entries))
+	for i, entry := range entries {
+		name := e.allowed[i]
+		if entry.Name != "" {
+			name = entry.Name
+		}
+		if _, ok := e.values[name]; ok {
+			panic(fmt.Sprintf("cli: enum name %q is used by more than one value", name))
+		}
+
+		e.names[entry.Value] = name
+		e.values[name] = entry.Value
+		e.allowed[i] = name
+		if entry.Help != "" {
+			e.help[name] = entry.Help
+		}
+	}
+
+	return e
+}
+
+// NewEnum creates a new type-safe enum flag in the same way as [Enum], but
+// returns an error if no allowed values are given or if any are duplicated.
+//
+//	format, err := cli.NewEnum(FormatJSON, FormatJSON, FormatYAML, FormatTOML)
+//	if err != nil {
+//	    return err
+//	}
+func NewEnum[T Enumerable](def T, allowed ...T) (*EnumValue[T], error) {
+	if len(allowed) == 0 {
+		return nil, errors.New("enum must have at least one allowed value")
+	}
+
+	seen := make(map[T]bool, len(allowed))
+	for _, v := range allowed {
+		if seen[v] {
+			return nil, fmt.Errorf("enum has a duplicate allowed value: %v", v)
+		}
+		seen[v] = true
+	}
+
+	return Enum(def, allowed...), nil
+}
+
+// MustEnum is like [NewEnum] but panics if the allowed values are invalid,
+// making it suitable for use inline when defining flags.
+//
+//	cmd.Flags().Var(cli.MustEnum(FormatJSON, FormatJSON, FormatYAML), "format", "output format")
+func MustEnum[T Enumerable](def T, allowed ...T) *EnumValue[T] {
+	e, err := NewEnum(def, allowed...)
+	if err != nil {
+		panic("cli: " + err.Error())
 	}
+	return e
 }
 
 // WithHelp adds help text for each enum value in order. The help strings
@@ -116,6 +225,84 @@ func (e *EnumValue[T]) WithHelp(help ...string) *EnumValue[T] {
 	return e
 }
 
+// WithNames replaces the display name of each enum value in order. Names are
+// used in help output, completion and when parsing, which is most useful for
+// integer-based enums that would otherwise display their numeric value. An
+// empty name keeps the existing display name.
+//
+// WithNames panics if two values end up sharing the same name, as this
+// indicates a programming error.
+//
+//	trust := cli.Enum(TrustUnknown, TrustUnknown, TrustNever, TrustMarginal).
+//	    WithNames("unknown", "never", "marginal")
+func (e *EnumValue[T]) WithNames(names ...string) *EnumValue[T] {
+	for i, name := range names {
+		if i >= len(e.allowed) || name == "" {
+			continue
+		}
+
+		prev := e.allowed[i]
+		v := e.values[prev]
+		delete(e.values, prev)
+		if _, ok := e.values[name]; ok {
+			panic(fmt.Sprintf("cli: enum name %q is used by more than one value", name))
+		}
+
+		if help, ok := e.help[prev]; ok {
+			delete(e.help, prev)
+			e.help[name] = help
+		}
+		e.names[v] = name
+		e.values[name] = v
+		e.allowed[i] = name
+	}
+
+	return e
+}
+
+// WithAliases registers alternative names that resolve to existing enum
+// values when parsing. Aliases are accepted on the command line but never
+// shown in help output or the type hint.
+//
+// WithAliases panics if an alias collides with an allowed value name or
+// refers to a value that is not allowed, as both indicate a programming error.
+//
+//	logLevel := cli.Enum(LogInfo, LogDebug, LogInfo, LogWarn, LogError).
+//	    WithAliases(map[string]LogLevel{
+//	        "warning": LogWarn,
+//	        "err":     LogError,
+//	    })
+func (e *EnumValue[T]) WithAliases(aliases map[string]T) *EnumValue[T] {
+	if e.aliases == nil {
+		e.aliases = make(map[string]T, len(aliases))
+	}
+
+	for alias, v := range aliases {
+		if _, ok := e.values[alias]; ok {
+			panic(fmt.Sprintf("cli: enum alias %q collides with an allowed value", alias))
+		}
+		if _, ok := e.names[v]; !ok {
+			panic(fmt.Sprintf("cli: enum alias %q refers to a value that is not allowed", alias))
+		}
+		e.aliases[alias] = v
+	}
+
+	return e
+}
+
+// CaseInsensitive allows values to be matched irrespective of case, so
+// JSON, Json and json all resolve to the same value. The canonical casing
+// is still used when displaying the value. This has no effect on
+// integer-based enums.
+//
+//	format := cli.Enum(FormatJSON, FormatJSON, FormatYAML).CaseInsensitive()
+func (e *EnumValue[T]) CaseInsensitive() *EnumValue[T] {
+	if e.baseType == "string" {
+		e.caseInsensitive = true
+	}
+	return e
+}
+
 // String returns the string representation of the current value.
 func (e *EnumValue[T]) String() string {
 	if name, ok := e.names[e.value]; ok {
@@ -126,11 +313,72 @@ func (e *EnumValue[T]) String() string {
 
 // Set validates and sets the value from a string.
 func (e *EnumValue[T]) Set(s string) error {
+	v, err := e.parse(s)
+	if err != nil {
+		return err
+	}
+	e.value = v
+	return nil
+}
+
+func (e *EnumValue[T]) parse(s string) (T, error) {
 	if v, ok := e.values[s]; ok {
-		e.value = v
-		return nil
+		return v, nil
+	}
+	if v, ok := e.aliases[s]; ok {
+		return v, nil
+	}
+	if e.caseInsensitive {
+		for name, v := range e.values {
+			if strings.EqualFold(name, s) {
+				return v, nil
+			}
+		}
+		for alias, v := range e.aliases {
+			if strings.EqualFold(alias, s) {
+				return v, nil
+			}
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("must be one of: %s", strings.Join(e.allowed, ", "))
+}
+
+// MarshalText implements [encoding.TextMarshaler], encoding the current value
+// by its display name.
+func (e *EnumValue[T]) MarshalText() ([]byte, error) {
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], validating the name in
+// the same way as [EnumValue.Set]. This allows an enum to back a field within
+// a config file as well as a flag. It must be called on a value created by
+// [Enum], so the allowed values are known.
+//
+//	cfg := struct {
+//	    Format *cli.EnumValue[Format] `json:"format"`
+//	}{
+//	    Format: cli.Enum(FormatJSON, FormatJSON, FormatYAML),
+//	}
+//	err := json.Unmarshal(data, &cfg)
+func (e *EnumValue[T]) UnmarshalText(text []byte) error {
+	return e.Set(string(text))
+}
+
+// MarshalJSON implements [json.Marshaler], encoding the current value as a
+// JSON string of its display name.
+func (e *EnumValue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements [json.Unmarshaler], decoding a JSON string and
+// validating it in the same way as [EnumValue.Set].
+func (e *EnumValue[T]) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
 	}
-	return fmt.Errorf("must be one of: %s", strings.Join(e.allowed, ", "))
+	return e.Set(name)
 }
 
 // Type returns the type name for help output, showing all allowed values.
@@ -138,6 +386,12 @@ func (e *EnumValue[T]) Type() string {
 	return strings.Join(e.allowed, "|")
 }
 
+// Reset restores the default value given to [Enum], allowing the same flag
+// to be reused across multiple executions, such as within tests.
+func (e *EnumValue[T]) Reset() {
+	e.value = e.defaultValue
+}
+
 // Get returns the current typed enum value.
 //
 //nolint:ireturn
@@ -166,3 +420,143 @@ func (e *EnumValue[T]) HelpEntries() []EnumOption {
 func (e *EnumValue[T]) BaseType() string {
 	return e.baseType
 }
+
+// DefaultName returns the display name of the default value, or an empty
+// string if the default is not one of the allowed values.
+func (e *EnumValue[T]) DefaultName() string {
+	return e.names[e.defaultValue]
+}
+
+// Values returns the display names of the allowed values in the order they
+// were defined. The returned slice is a copy and can be safely modified.
+//
+//	format := cli.Enum(FormatJSON, FormatJSON, FormatYAML, FormatTOML)
+//	fmt.Println(strings.Join(format.Values(), ", ")) // json, yaml, toml
+func (e *EnumValue[T]) Values() []string {
+	return slices.Clone(e.allowed)
+}
+
+// EnumSliceValue implements pflag.Value and pflag.SliceValue for repeatable,
+// type-safe enumeration flags.
+type EnumSliceValue[T Enumerable] struct {
+	enum  *EnumValue[T]
+	value []T
+}
+
+// EnumSlice creates a new type-safe enum flag that accepts multiple values,
+// either as a comma-separated list or by repeating the flag. Every value is
+// validated against the allowed set.
+//
+//	type Feature string
+//
+//	const (
+//	    FeatureCache   Feature = "cache"
+//	    FeatureMetrics Feature = "metrics"
+//	    FeatureTracing Feature = "tracing"
+//	)
+//
+//	features := cli.EnumSlice(FeatureCache, FeatureMetrics, FeatureTracing)
+//	cmd.Flags().Var(features, "feature", "enable optional features")
+//
+// Both --feature cache,metrics and --feature cache --feature metrics
+// result in the same set of values.
+func EnumSlice[T Enumerable](allowed ...T) *EnumSliceValue[T] {
+	var def T
+	return &EnumSliceValue[T]{
+		enum: Enum(def, allowed...),
+	}
+}
+
+// WithHelp adds help text for each enum value in order. The help strings
+// correspond to the enum values in the order they were defined.
+func (e *EnumSliceValue[T]) WithHelp(help ...string) *EnumSliceValue[T] {
+	e.enum.WithHelp(help...)
+	return e
+}
+
+// String returns the string representation of the current values.
+func (e *EnumSliceValue[T]) String() string {
+	return "[" + strings.Join(e.GetSlice(), ",") + "]"
+}
+
+// Set validates and appends one or more comma-separated values.
+func (e *EnumSliceValue[T]) Set(s string) error {
+	return e.Append(s)
+}
+
+// Type returns the type name for help output, showing all allowed values.
+func (e *EnumSliceValue[T]) Type() string {
+	return e.enum.Type()
+}
+
+// Append validates and appends one or more comma-separated values.
+func (e *EnumSliceValue[T]) Append(s string) error {
+	parsed, err := e.parseAll(strings.Split(s, ","))
+	if err != nil {
+		return err
+	}
+	e.value = append(e.value, parsed...)
+	return nil
+}
+
+// Replace validates and replaces all current values.
+func (e *EnumSliceValue[T]) Replace(vals []string) error {
+	parsed, err := e.parseAll(vals)
+	if err != nil {
+		return err
+	}
+	e.value = parsed
+	return nil
+}
+
+// GetSlice returns the display names of the current values.
+func (e *EnumSliceValue[T]) GetSlice() []string {
+	names := make([]string, len(e.value))
+	for i, v := range e.value {
+		names[i] = e.enum.names[v]
+	}
+	return names
+}
+
+// Get returns the current typed enum values.
+func (e *EnumSliceValue[T]) Get() []T {
+	return slices.Clone(e.value)
+}
+
+// HasHelp returns true if this enum has help text for its values.
+func (e *EnumSliceValue[T]) HasHelp() bool {
+	return e.enum.HasHelp()
+}
+
+// HelpEntries returns the enum values with their help text in display order.
+func (e *EnumSliceValue[T]) HelpEntries() []EnumOption {
+	return e.enum.HelpEntries()
+}
+
+// BaseType returns the underlying slice type name ("stringSlice" or "intSlice").
+func (e *EnumSliceValue[T]) BaseType() string {
+	return e.enum.BaseType() + "Slice"
+}
+
+// DefaultName returns an empty string, as an enum slice has no default values.
+func (e *EnumSliceValue[T]) DefaultName() string {
+	return ""
+}
+
+// Values returns the display names of the allowed values in the order they
+// were defined. The returned slice is a copy and can be safely modified.
+func (e *EnumSliceValue[T]) Values() []string {
+	return e.enum.Values()
+}
+
+func (e *EnumSliceValue[T]) parseAll(vals []string) ([]T, error) {
+	parsed := make([]T, 0, len(vals))
+	for _, s := range vals {
+		v, err := e.enum.parse(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, v)
+	}
+	return parsed, nil
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,3 +75,480 @@ func TestEnumSetFailsWithUnmatchedValue(t *testing.T) {
 	assert.Contains(t, err.Error(), "must be one of")
 	assert.Equal(t, FormatJSON, e.Get()) // unchanged
 }
+
+func TestEnumWithNames(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota + 1
+		TrustNever
+		TrustMarginal
+	)
+
+	e := Enum(TrustUnknown, TrustUnknown, TrustNever, TrustMarginal).
+		WithNames("unknown", "never", "marginal")
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(e, "trust-level", "GPG trust level")
+	cmd.SetArgs([]string{"--trust-level", "marginal"})
+
+	var buf bytes.Buffer
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	assert.Equal(t, TrustMarginal, e.Get())
+	assert.Equal(t, "marginal", e.String())
+	assert.Equal(t, "unknown|never|marginal", e.Type())
+	assert.Equal(t, "unknown", e.DefaultName())
+
+	err = e.Set("3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of: unknown, never, marginal")
+}
+
+func TestEnumWithNamesInHelp(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota + 1
+		TrustNever
+		TrustMarginal
+	)
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(Enum(TrustUnknown, TrustUnknown, TrustNever, TrustMarginal).
+		WithNames("unknown", "never", "marginal"), "trust-level", "GPG trust level")
+	cmd.SetArgs([]string{"--help"})
+
+	var buf bytes.Buffer
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "--trust-level <unknown|never|marginal>")
+}
+
+func TestEnumWithNamesCollisionPanics(t *testing.T) {
+	type Format string
+	assert.Panics(t, func() {
+		Enum(Format("json"), "json", "yaml").WithNames("", "json")
+	})
+}
+
+func TestEnumReset(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	e := Enum(FormatJSON, FormatJSON, FormatYAML)
+	require.NoError(t, e.Set("yaml"))
+	assert.Equal(t, FormatYAML, e.Get())
+
+	e.Reset()
+	assert.Equal(t, FormatJSON, e.Get())
+	assert.Equal(t, "json", e.String())
+}
+
+func TestEnumDefaultName(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota
+		TrustNever
+	)
+
+	e := Enum(TrustUnknown, TrustUnknown, TrustNever)
+	require.NoError(t, e.Set("1"))
+
+	assert.Equal(t, "0", e.DefaultName())
+	assert.Equal(t, "1", e.String())
+}
+
+func TestEnumValues(t *testing.T) {
+	e := Enum("yaml", "yaml", "json", "toml")
+
+	values := e.Values()
+	assert.Equal(t, []string{"yaml", "json", "toml"}, values)
+
+	values[0] = "xml"
+	assert.Equal(t, []string{"yaml", "json", "toml"}, e.Values())
+	require.Error(t, e.Set("xml"))
+}
+
+func TestEnumValuesWithNames(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota
+		TrustNever
+	)
+
+	e := Enum(TrustUnknown, TrustUnknown, TrustNever).WithNames("unknown", "never")
+	assert.Equal(t, []string{"unknown", "never"}, e.Values())
+}
+
+func TestEnumFrom(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota + 1
+		TrustNever
+		TrustMarginal
+	)
+
+	e := EnumFrom([]EnumEntry[TrustLevel]{
+		{Value: TrustUnknown, Name: "unknown", Help: "trust has not been assigned"},
+		{Value: TrustNever, Name: "never"},
+		{Value: TrustMarginal, Name: "marginal", Help: "the key is marginally trusted"},
+	}, TrustUnknown)
+
+	assert.Equal(t, "int", e.BaseType())
+	assert.Equal(t, "unknown", e.String())
+	assert.Equal(t, "unknown|never|marginal", e.Type())
+	assert.True(t, e.HasHelp())
+	assert.Equal(t, []EnumOption{
+		{Name: "unknown", Help: "trust has not been assigned"},
+		{Name: "never"},
+		{Name: "marginal", Help: "the key is marginally trusted"},
+	}, e.HelpEntries())
+
+	require.NoError(t, e.Set("marginal"))
+	assert.Equal(t, TrustMarginal, e.Get())
+	require.Error(t, e.Set("3"))
+}
+
+func TestEnumFromWithoutNames(t *testing.T) {
+	e := EnumFrom([]EnumEntry[string]{
+		{Value: "json"},
+		{Value: "yaml"},
+	}, "json")
+
+	assert.Equal(t, "json|yaml", e.Type())
+	assert.False(t, e.HasHelp())
+
+	require.NoError(t, e.Set("yaml"))
+	assert.Equal(t, "yaml", e.Get())
+}
+
+func TestEnumFromDuplicateNamePanics(t *testing.T) {
+	assert.PanicsWithValue(t, `cli: enum name "json" is used by more than one value`, func() {
+		EnumFrom([]EnumEntry[int]{
+			{Value: 1, Name: "json"},
+			{Value: 2, Name: "json"},
+		}, 1)
+	})
+}
+
+func TestEnumFromInvalidPanics(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []EnumEntry[int]
+		err     string
+	}{
+		{name: "Empty", entries: nil, err: "cli: enum must have at least one allowed value"},
+		{
+			name:    "DuplicateValue",
+			entries: []EnumEntry[int]{{Value: 1, Name: "json"}, {Value: 1, Name: "yaml"}},
+			err:     "cli: enum has a duplicate allowed value: 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.PanicsWithValue(t, tt.err, func() {
+				EnumFrom(tt.entries, 1)
+			})
+		})
+	}
+}
+
+func TestEnumFromNameMatchingAnotherValue(t *testing.T) {
+	e := EnumFrom([]EnumEntry[int]{
+		{Value: 1, Name: "2"},
+		{Value: 2, Name: "two"},
+	}, 1)
+
+	assert.Equal(t, "2|two", e.Type())
+
+	require.NoError(t, e.Set("2"))
+	assert.Equal(t, 1, e.Get())
+	require.NoError(t, e.Set("two"))
+	assert.Equal(t, 2, e.Get())
+}
+
+func TestEnumCaseInsensitive(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	tests := []struct {
+		name  string
+		input string
+		want  Format
+	}{
+		{name: "WithUpperCase", input: "YAML", want: FormatYAML},
+		{name: "WithMixedCase", input: "Json", want: FormatJSON},
+		{name: "WithCanonicalCase", input: "yaml", want: FormatYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Enum(FormatJSON, FormatJSON, FormatYAML).CaseInsensitive()
+
+			require.NoError(t, e.Set(tt.input))
+			assert.Equal(t, tt.want, e.Get())
+			assert.Equal(t, string(tt.want), e.String())
+			assert.Equal(t, "json|yaml", e.Type())
+		})
+	}
+}
+
+func TestEnumCaseSensitiveByDefault(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	e := Enum(FormatJSON, FormatJSON, FormatYAML)
+
+	err := e.Set("YAML")
+	require.Error(t, err)
+	assert.Equal(t, FormatJSON, e.Get())
+}
+
+func TestEnumCaseInsensitiveIgnoredForInt(t *testing.T) {
+	type TrustLevel int
+	const (
+		TrustUnknown TrustLevel = iota + 1
+		TrustNever
+	)
+
+	e := Enum(TrustUnknown, TrustUnknown, TrustNever).CaseInsensitive()
+
+	assert.False(t, e.caseInsensitive)
+	require.NoError(t, e.Set("2"))
+	assert.Equal(t, TrustNever, e.Get())
+}
+
+func TestEnumWithAliases(t *testing.T) {
+	e := Enum(LogInfo, LogDebug, LogInfo, LogWarn, LogError).
+		WithAliases(map[string]LogLevel{"warning": LogWarn})
+
+	require.NoError(t, e.Set("warning"))
+	assert.Equal(t, LogWarn, e.Get())
+	assert.Equal(t, "warn", e.String())
+	assert.Equal(t, "debug|info|warn|error", e.Type())
+
+	var names []string
+	for _, entry := range e.HelpEntries() {
+		names = append(names, entry.Name)
+	}
+	assert.Equal(t, []string{"debug", "info", "warn", "error"}, names)
+}
+
+func TestEnumWithAliasesCaseInsensitive(t *testing.T) {
+	e := Enum(LogInfo, LogDebug, LogInfo, LogWarn, LogError).
+		WithAliases(map[string]LogLevel{"warning": LogWarn}).
+		CaseInsensitive()
+
+	require.NoError(t, e.Set("WARNING"))
+	assert.Equal(t, LogWarn, e.Get())
+}
+
+func TestEnumWithAliasesCollisionPanics(t *testing.T) {
+	assert.PanicsWithValue(t, `cli: enum alias "info" collides with an allowed value`, func() {
+		Enum(LogInfo, LogDebug, LogInfo, LogWarn).
+			WithAliases(map[string]LogLevel{"info": LogWarn})
+	})
+}
+
+func TestEnumWithAliasesUnknownValuePanics(t *testing.T) {
+	assert.PanicsWithValue(t, `cli: enum alias "fatal" refers to a value that is not allowed`, func() {
+		Enum(LogInfo, LogDebug, LogInfo).
+			WithAliases(map[string]LogLevel{"fatal": LogError})
+	})
+}
+
+func TestEnumTextRoundTrip(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	e := Enum(FormatJSON, FormatJSON, FormatYAML)
+	require.NoError(t, e.UnmarshalText([]byte("yaml")))
+	assert.Equal(t, FormatYAML, e.Get())
+
+	text, err := e.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", string(text))
+}
+
+func TestEnumJSONRoundTrip(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	type config struct {
+		Format *EnumValue[Format] `json:"format"`
+	}
+
+	cfg := config{Format: Enum(FormatJSON, FormatJSON, FormatYAML)}
+	require.NoError(t, json.Unmarshal([]byte(`{"format":"yaml"}`), &cfg))
+	assert.Equal(t, FormatYAML, cfg.Format.Get())
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"format":"yaml"}`, string(data))
+}
+
+func TestEnumUnmarshalFailsWithUnmatchedValue(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	tests := []struct {
+		name      string
+		unmarshal func(*EnumValue[Format]) error
+	}{
+		{
+			name:      "Text",
+			unmarshal: func(e *EnumValue[Format]) error { return e.UnmarshalText([]byte("xml")) },
+		},
+		{
+			name:      "JSON",
+			unmarshal: func(e *EnumValue[Format]) error { return json.Unmarshal([]byte(`"xml"`), e) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := Enum(FormatJSON, FormatJSON, FormatYAML)
+
+			err := tt.unmarshal(e)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "must be one of")
+			assert.Equal(t, FormatJSON, e.Get())
+		})
+	}
+}
+
+func TestNewEnum(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	e, err := NewEnum(FormatJSON, FormatJSON, FormatYAML)
+	require.NoError(t, err)
+	assert.Equal(t, "json|yaml", e.Type())
+}
+
+func TestNewEnumInvalid(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	tests := []struct {
+		name    string
+		allowed []Format
+		err     string
+	}{
+		{name: "Empty", allowed: nil, err: "enum must have at least one allowed value"},
+		{name: "Duplicate", allowed: []Format{FormatJSON, FormatYAML, FormatJSON}, err: "enum has a duplicate allowed value: json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewEnum(FormatJSON, tt.allowed...)
+			require.EqualError(t, err, tt.err)
+
+			assert.PanicsWithValue(t, "cli: "+tt.err, func() {
+				MustEnum(FormatJSON, tt.allowed...)
+			})
+		})
+	}
+}
+
+func TestEnumSliceCommaSeparated(t *testing.T) {
+	type Feature string
+	const (
+		FeatureCache   Feature = "cache"
+		FeatureMetrics Feature = "metrics"
+		FeatureTracing Feature = "tracing"
+	)
+
+	e := EnumSlice(FeatureCache, FeatureMetrics, FeatureTracing)
+
+	require.NoError(t, e.Set("cache,tracing"))
+	assert.Equal(t, []Feature{FeatureCache, FeatureTracing}, e.Get())
+	assert.Equal(t, "[cache,tracing]", e.String())
+	assert.Equal(t, "cache|metrics|tracing", e.Type())
+}
+
+func TestEnumSliceRepeatedFlag(t *testing.T) {
+	type Feature string
+	const (
+		FeatureCache   Feature = "cache"
+		FeatureMetrics Feature = "metrics"
+	)
+
+	var buf bytes.Buffer
+	features := EnumSlice(FeatureCache, FeatureMetrics)
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(features, "feature", "enable optional features")
+	cmd.SetArgs([]string{"--feature", "cache", "--feature", "metrics"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, []Feature{FeatureCache, FeatureMetrics}, features.Get())
+}
+
+func TestEnumSliceSetFailsWithUnmatchedValue(t *testing.T) {
+	type Feature string
+	const (
+		FeatureCache   Feature = "cache"
+		FeatureMetrics Feature = "metrics"
+	)
+
+	e := EnumSlice(FeatureCache, FeatureMetrics)
+
+	err := e.Set("cache,profiling")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be one of: cache, metrics")
+	assert.Empty(t, e.Get()) // unchanged
+}
+
+func TestEnumSliceInfersCompletions(t *testing.T) {
+	type Feature string
+	const (
+		FeatureCache   Feature = "cache"
+		FeatureMetrics Feature = "metrics"
+	)
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(EnumSlice(FeatureCache, FeatureMetrics), "feature", "enable optional features")
+
+	actions := inferFlagCompletions(cmd)
+	assert.Contains(t, actions, "feature")
+}
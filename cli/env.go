@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -20,6 +21,9 @@ const envVarAnnotation = "purpleclay_cli_env"
 //  2. Environment variable
 //  3. Flag default value
 //
+// Use [WithEnvOverridesFlags] to give the environment variable precedence
+// over an explicit flag value.
+//
 // If flag is nil, BindEnv silently returns without effect (no-op).
 //
 //	cmd.Flags().StringVarP(&key, "key", "k", "", "GPG private key")
@@ -35,6 +39,34 @@ func BindEnv(flag *pflag.Flag, envVar string) {
 	flag.Annotations[envVarAnnotation] = []string{envVar}
 }
 
+// BindEnvPrefix binds every flag of cmd and its subcommands to an environment
+// variable derived from the flag name. The name is uppercased, dashes are
+// replaced with underscores and the prefix is prepended. Flags already bound
+// through [BindEnv] keep their explicit environment variable.
+//
+//	cli.BindEnvPrefix(root, "MYAPP")
+//	// --log-level is bound to MYAPP_LOG_LEVEL
+func BindEnvPrefix(cmd *cobra.Command, prefix string) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if GetEnvVar(f) != "" {
+			return
+		}
+		BindEnv(f, envVarName(prefix, f.Name))
+	})
+
+	for _, sub := range cmd.Commands() {
+		BindEnvPrefix(sub, prefix)
+	}
+}
+
+func envVarName(prefix, flag string) string {
+	name := strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+	if prefix == "" {
+		return name
+	}
+	return strings.TrimSuffix(prefix, "_") + "_" + name
+}
+
 // GetEnvVar returns the environment variable associated with a flag,
 // or an empty string if no binding exists.
 func GetEnvVar(flag *pflag.Flag) string {
@@ -47,43 +79,65 @@ func GetEnvVar(flag *pflag.Flag) string {
 	return ""
 }
 
-func applyEnvBindings(cmd *cobra.Command) error {
-	var applyErr error
+// addEnvBindings applies environment variables to the flags of the executed
+// command once cobra has parsed the command line, leaving any explicitly set
+// flag untouched. Flags of commands that are not executed are never modified.
+func addEnvBindings(cmd *cobra.Command) {
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		var applyErr error
 
-	cmd.Flags().VisitAll(func(f *pflag.Flag) {
-		if applyErr != nil {
-			return
-		}
-		if err := applyEnvToFlag(f); err != nil {
-			applyErr = err
-		}
+		// Once parsed, the flags of a command include those it inherits
+		c.Flags().VisitAll(func(f *pflag.Flag) {
+			if applyErr != nil {
+				return
+			}
+			if err := applyEnvToFlag(f, false); err != nil {
+				applyErr = err
+			}
+		})
+		return applyErr
 	})
+}
 
-	if applyErr != nil {
+// addEnvOverrides reapplies environment variables to explicitly set flags
+// once cobra has parsed the command line, allowing them to take precedence.
+func addEnvOverrides(cmd *cobra.Command) {
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		var applyErr error
+		c.Flags().Visit(func(f *pflag.Flag) {
+			if applyErr != nil {
+				return
+			}
+			if err := applyEnvToFlag(f, true); err != nil {
+				applyErr = err
+			}
+		})
 		return applyErr
-	}
-
-	for _, sub := range cmd.Commands() {
-		if err := applyEnvBindings(sub); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	})
 }
 
-func applyEnvToFlag(flag *pflag.Flag) error {
+func applyEnvToFlag(flag *pflag.Flag, override bool) error {
 	envVar := GetEnvVar(flag)
 	if envVar == "" {
 		return nil
 	}
 
 	val := os.Getenv(envVar)
-	if val == "" || flag.Changed {
+	if val == "" || (flag.Changed && !override) {
 		return nil
 	}
 
-	if err := flag.Value.Set(val); err != nil {
+	var err error
+	if sv, ok := flag.Value.(pflag.SliceValue); ok && flag.Changed {
+		// Setting a slice that was changed appends to the existing values
+		err = sv.Replace(strings.Split(val, ","))
+	} else {
+		err = flag.Value.Set(val)
+	}
+	if err != nil {
+		if IsFlagSecret(flag) {
+			return fmt.Errorf("invalid value for --%s from environment variable %s", flag.Name, envVar)
+		}
 		return fmt.Errorf("invalid value for --%s from environment variable %s: %w", flag.Name, envVar, err)
 	}
 
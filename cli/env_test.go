@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -62,3 +63,249 @@ func TestBindEnvInvalidValueReturnsError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid value for --port from environment variable TEST_PORT")
 }
+
+func TestBindEnvHiddenFlag(t *testing.T) {
+	t.Setenv("TEST_KEY", "from-env")
+
+	var buf bytes.Buffer
+	var val string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(&val, "key", "default", "test flag")
+	require.NoError(t, cmd.Flags().MarkHidden("key"))
+	BindEnv(cmd.Flags().Lookup("key"), "TEST_KEY")
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", val)
+}
+
+func TestBindEnvOnlyExecutedCommand(t *testing.T) {
+	t.Setenv("TEST_PORT", "not-a-number")
+
+	var buf bytes.Buffer
+	var name string
+
+	root := &cobra.Command{Use: "test"}
+	serve := &cobra.Command{
+		Use: "serve",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	serve.Flags().Int("port", 8080, "port to listen on")
+	BindEnv(serve.Flags().Lookup("port"), "TEST_PORT")
+
+	greet := &cobra.Command{
+		Use: "greet",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	greet.Flags().StringVar(&name, "name", "world", "name to greet")
+
+	root.AddCommand(serve, greet)
+	root.SetArgs([]string{"greet"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, "world", name)
+	assert.Equal(t, "8080", serve.Flags().Lookup("port").Value.String())
+}
+
+func TestEnvVarName(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		flag     string
+		expected string
+	}{
+		{name: "Simple", prefix: "MYAPP", flag: "token", expected: "MYAPP_TOKEN"},
+		{name: "Dashes", prefix: "MYAPP", flag: "log-level", expected: "MYAPP_LOG_LEVEL"},
+		{name: "TrailingUnderscore", prefix: "MYAPP_", flag: "log-level", expected: "MYAPP_LOG_LEVEL"},
+		{name: "NoPrefix", prefix: "", flag: "log-level", expected: "LOG_LEVEL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, envVarName(tt.prefix, tt.flag))
+		})
+	}
+}
+
+func TestBindEnvPrefix(t *testing.T) {
+	t.Setenv("MYAPP_LOG_LEVEL", "debug")
+	t.Setenv("MYAPP_DRY_RUN", "true")
+
+	var buf bytes.Buffer
+	var logLevel string
+	var dryRun bool
+
+	root := &cobra.Command{
+		Use: "myapp",
+	}
+	root.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level")
+
+	sub := &cobra.Command{
+		Use: "deploy",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	sub.Flags().BoolVar(&dryRun, "dry-run", false, "dry run")
+	root.AddCommand(sub)
+
+	BindEnvPrefix(root, "MYAPP")
+	root.SetArgs([]string{"deploy"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", logLevel)
+	assert.True(t, dryRun)
+	assert.Equal(t, "MYAPP_DRY_RUN", GetEnvVar(sub.Flags().Lookup("dry-run")))
+}
+
+func TestBindEnvPrefixExplicitBindingTakesPrecedence(t *testing.T) {
+	cmd := &cobra.Command{Use: "myapp"}
+	cmd.Flags().String("token", "", "API token")
+	cmd.Flags().String("log-level", "", "log level")
+
+	BindEnv(cmd.Flags().Lookup("token"), "GITHUB_TOKEN")
+	BindEnvPrefix(cmd, "MYAPP")
+
+	assert.Equal(t, "GITHUB_TOKEN", GetEnvVar(cmd.Flags().Lookup("token")))
+	assert.Equal(t, "MYAPP_LOG_LEVEL", GetEnvVar(cmd.Flags().Lookup("log-level")))
+}
+
+func TestWithAutoEnv(t *testing.T) {
+	t.Setenv("MYAPP_LOG_LEVEL", "debug")
+	t.Setenv("MYAPP_DRY_RUN", "true")
+
+	var buf bytes.Buffer
+	var logLevel string
+	var dryRun bool
+
+	root := &cobra.Command{
+		Use: "myapp",
+	}
+	root.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level")
+
+	sub := &cobra.Command{
+		Use: "deploy",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	sub.Flags().BoolVar(&dryRun, "dry-run", false, "dry run")
+	root.AddCommand(sub)
+
+	err := Execute(root, WithStdout(&buf), WithAutoEnv("MYAPP"), WithArgs("deploy"))
+	require.NoError(t, err)
+	assert.Equal(t, "debug", logLevel)
+	assert.True(t, dryRun)
+}
+
+func TestWithAutoEnvExplicitBindingTakesPrecedence(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "explicit")
+	t.Setenv("MYAPP_TOKEN", "derived")
+
+	var buf bytes.Buffer
+	var token string
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().StringVar(&token, "token", "", "API token")
+	BindEnv(cmd.Flags().Lookup("token"), "GITHUB_TOKEN")
+
+	err := Execute(cmd, WithStdout(&buf), WithAutoEnv("MYAPP"), WithArgs())
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", token)
+	assert.Equal(t, "GITHUB_TOKEN", GetEnvVar(cmd.Flags().Lookup("token")))
+}
+
+func TestWithAutoEnvSkipsFrameworkFlags(t *testing.T) {
+	stubInputIsTerminal(t, true)
+	t.Setenv("MYAPP_VERSION", "1.2.3")
+	t.Setenv("MYAPP_YES", "true")
+	t.Setenv("MYAPP_TOKEN", "derived")
+
+	var stdout, stderr bytes.Buffer
+	var token string
+	var confirmed bool
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		RunE: func(c *cobra.Command, _ []string) error {
+			var err error
+			confirmed, err = Confirm(c, "Delete every release?")
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&token, "token", "", "API token")
+
+	err := Execute(cmd,
+		WithStdin(strings.NewReader("n\n")),
+		WithStdout(&stdout),
+		WithStderr(&stderr),
+		WithAutoEnv("MYAPP"),
+		WithVersionFlag(VersionInfo{Version: "0.1.0"}),
+		WithConfirmFlag(),
+		WithArgs())
+	require.NoError(t, err)
+
+	assert.Equal(t, "derived", token)
+	assert.False(t, confirmed)
+	assert.Contains(t, stderr.String(), "Delete every release?")
+	assert.Empty(t, stdout.String())
+	assert.Empty(t, GetEnvVar(cmd.Flags().Lookup("version")))
+	assert.Empty(t, GetEnvVar(cmd.PersistentFlags().Lookup("yes")))
+}
+
+func TestWithEnvOverridesFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		args     []string
+		expected string
+	}{
+		{name: "FlagWinsByDefault", args: []string{"--key=from-flag"}, expected: "from-flag"},
+		{name: "EnvWinsWhenEnabled", opts: []Option{WithEnvOverridesFlags()}, args: []string{"--key=from-flag"}, expected: "from-env"},
+		{name: "EnvAppliesWithoutFlag", opts: []Option{WithEnvOverridesFlags()}, args: []string{}, expected: "from-env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_KEY", "from-env")
+
+			var val string
+			cmd := &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().StringVar(&val, "key", "", "test flag")
+			BindEnv(cmd.Flags().Lookup("key"), "TEST_KEY")
+			cmd.SetArgs(tt.args)
+
+			err := Execute(cmd, append([]Option{WithStdout(&bytes.Buffer{})}, tt.opts...)...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+}
+
+func TestWithEnvOverridesFlagsInheritedSlice(t *testing.T) {
+	t.Setenv("TEST_TAGS", "a,b")
+
+	var tags []string
+	root := &cobra.Command{Use: "test"}
+	root.PersistentFlags().StringSliceVar(&tags, "tag", nil, "tags")
+	BindEnv(root.PersistentFlags().Lookup("tag"), "TEST_TAGS")
+
+	sub := &cobra.Command{
+		Use: "sub",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	root.AddCommand(sub)
+	root.SetArgs([]string{"sub", "--tag", "c"})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}), WithEnvOverridesFlags())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, tags)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// ErrorFormat controls how [Execute] reports an error returned from a command.
+type ErrorFormat string
+
+const (
+	// ErrorFormatText prints errors as human-readable text. This is the
+	// default.
+	ErrorFormatText ErrorFormat = "text"
+
+	// ErrorFormatJSON prints errors as a single JSON object, for consumption
+	// by tools that wrap the CLI.
+	ErrorFormatJSON ErrorFormat = "json"
+)
+
+// WithErrorFormat sets the format used to print an error returned from a
+// command to stderr. With [ErrorFormatJSON], the error is written as a JSON
+// object containing the message and the path of the command that failed,
+// along with the code of any wrapped [ExitError]. Usage is never printed in
+// this format, unless enabled through [WithSilenceUsage]. It panics if the
+// format is not supported.
+//
+//	cli.Execute(root, cli.WithErrorFormat(cli.ErrorFormatJSON))
+//
+// Produces:
+//
+//	{"error":"invalid config","command":"app next","code":2}
+func WithErrorFormat(format ErrorFormat) Option {
+	switch format {
+	case ErrorFormatText, ErrorFormatJSON:
+	default:
+		panic(fmt.Sprintf("cli: unsupported error format: %s", format))
+	}
+
+	return func(o *options) {
+		o.errorFormat = format
+	}
+}
+
+type jsonError struct {
+	Error   string `json:"error"`
+	Command string `json:"command"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// executeJSONErrors executes the command, taking over from cobra the printing
+// of any returned error so that it can be written as JSON.
+func executeJSONErrors(ctx context.Context, cmd *cobra.Command) error {
+	silenced := cmd.SilenceErrors
+	cmd.SilenceErrors = true
+
+	c, err := cmd.ExecuteContextC(ctx)
+	if err != nil && !silenced && !c.SilenceErrors {
+		writeJSONError(cmd.ErrOrStderr(), c, err)
+	}
+	return err
+}
+
+func writeJSONError(w io.Writer, cmd *cobra.Command, err error) {
+	jerr := jsonError{
+		Error:   err.Error(),
+		Command: cmd.CommandPath(),
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		jerr.Code = exitErr.Code
+	}
+
+	data, _ := json.Marshal(jerr)
+	fmt.Fprintln(w, string(data))
+}
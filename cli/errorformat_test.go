@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newErrorFormatCommand(err error) *cobra.Command {
+	root := &cobra.Command{Use: "app"}
+	root.AddCommand(&cobra.Command{
+		Use: "next",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return err
+		},
+	})
+	return root
+}
+
+func TestWithErrorFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   ErrorFormat
+		err      error
+		expected string
+	}{
+		{
+			name:     "Text",
+			format:   ErrorFormatText,
+			err:      errors.New("invalid config"),
+			expected: "Error: invalid config\n",
+		},
+		{
+			name:     "JSON",
+			format:   ErrorFormatJSON,
+			err:      errors.New("invalid config"),
+			expected: `{"error":"invalid config","command":"app next"}` + "\n",
+		},
+		{
+			name:     "JSONWithExitCode",
+			format:   ErrorFormatJSON,
+			err:      fmt.Errorf("next: %w", &ExitError{Code: 2, Err: errors.New("invalid config")}),
+			expected: `{"error":"next: invalid config","command":"app next","code":2}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			err := Execute(newErrorFormatCommand(tt.err),
+				WithStdout(&stdout),
+				WithStderr(&stderr),
+				WithSilenceUsage(true),
+				WithErrorFormat(tt.format),
+				WithArgs("next"))
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.expected, stderr.String())
+		})
+	}
+}
+
+func TestWithErrorFormatJSONMisuse(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := Execute(newErrorFormatCommand(nil),
+		WithStdout(&stdout),
+		WithStderr(&stderr),
+		WithErrorFormat(ErrorFormatJSON),
+		WithArgs("next", "--unknown"))
+	require.Error(t, err)
+	assert.Equal(t, `{"error":"unknown flag: --unknown","command":"app next"}`+"\n", stderr.String())
+}
+
+func TestWithErrorFormatJSONSilenced(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	err := Execute(newErrorFormatCommand(errors.New("invalid config")),
+		WithStdout(&stdout),
+		WithStderr(&stderr),
+		WithSilenceErrors(true),
+		WithErrorFormat(ErrorFormatJSON),
+		WithArgs("next"))
+	require.Error(t, err)
+	assert.Empty(t, stderr.String())
+}
+
+func TestWithErrorFormatUnsupported(t *testing.T) {
+	assert.PanicsWithValue(t, "cli: unsupported error format: xml", func() {
+		WithErrorFormat("xml")
+	})
+}
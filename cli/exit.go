@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitError is an error that carries the exit code the CLI should terminate
+// with. Return it from a command to exit with a code other than 1 when using
+// [ExecuteMain].
+//
+//	return &cli.ExitError{Code: 2, Err: fmt.Errorf("invalid config: %w", err)}
+type ExitError struct {
+	// Code is the exit code of the process.
+	Code int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// WithExitCodeMapper sets the function used by [ExecuteMain] to map an error
+// returned from [Execute] to an exit code. The mapper is only called for
+// non-nil errors. By default, an [ExitError] exits with its code and any other
+// error exits with 1.
+//
+//	cli.ExecuteMain(root, cli.WithExitCodeMapper(func(err error) int {
+//	    if errors.Is(err, errValidation) {
+//	        return 2
+//	    }
+//	    return 1
+//	}))
+func WithExitCodeMapper(fn func(error) int) Option {
+	return func(o *options) {
+		o.exitCodeMapper = fn
+	}
+}
+
+// ExecuteMain runs [Execute] and terminates the process with an exit code
+// derived from the result, 0 on success. It replaces the common boilerplate
+// of checking the error from [Execute] and calling os.Exit.
+//
+//	func main() {
+//	    cli.ExecuteMain(root, cli.WithVersionCommand(info))
+//	}
+func ExecuteMain(cmd *cobra.Command, opts ...Option) {
+	o := resolveOptions(opts)
+	exit(exitCode(execute(cmd, o), o.exitCodeMapper))
+}
+
+func exitCode(err error, mapper func(error) int) int {
+	if err == nil {
+		return 0
+	}
+	if mapper != nil {
+		return mapper(err)
+	}
+	return defaultExitCode(err)
+}
+
+func defaultExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
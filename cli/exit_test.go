@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Success", err: nil, expected: 0},
+		{name: "Error", err: errors.New("boom"), expected: 1},
+		{name: "ExitError", err: &ExitError{Code: 3, Err: errors.New("boom")}, expected: 3},
+		{name: "WrappedExitError", err: fmt.Errorf("run: %w", &ExitError{Code: 4}), expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, exitCode(tt.err, nil))
+		})
+	}
+}
+
+func TestExitCodeWithMapper(t *testing.T) {
+	errValidation := errors.New("validation failed")
+	mapper := func(err error) int {
+		if errors.Is(err, errValidation) {
+			return 2
+		}
+		return 5
+	}
+
+	assert.Equal(t, 0, exitCode(nil, mapper))
+	assert.Equal(t, 2, exitCode(fmt.Errorf("config: %w", errValidation), mapper))
+	assert.Equal(t, 5, exitCode(errors.New("boom"), mapper))
+}
+
+func TestExitErrorMessage(t *testing.T) {
+	assert.Equal(t, "boom", (&ExitError{Code: 2, Err: errors.New("boom")}).Error())
+	assert.Equal(t, "exit status 2", (&ExitError{Code: 2}).Error())
+}
+
+func TestExecuteMain(t *testing.T) {
+	code := -1
+	exit = func(c int) { code = c }
+	t.Cleanup(func() { exit = os.Exit })
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return &ExitError{Code: 2, Err: errors.New("invalid input")}
+		},
+	}
+	cmd.SetArgs([]string{})
+
+	ExecuteMain(cmd, WithStdout(io.Discard), WithStderr(io.Discard), WithExitCodeMapper(func(err error) int {
+		require.EqualError(t, err, "invalid input")
+		return 7
+	}))
+	assert.Equal(t, 7, code)
+}
+
+func TestExecuteMainAppliesOptionsOnce(t *testing.T) {
+	exit = func(int) {}
+	t.Cleanup(func() { exit = os.Exit })
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.SetArgs([]string{})
+
+	applied := 0
+	ExecuteMain(cmd, WithStdout(io.Discard), func(*options) { applied++ })
+	assert.Equal(t, 1, applied)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -11,56 +12,311 @@ import (
 	"github.com/spf13/pflag"
 )
 
-func helpFunc(theme Theme, width int) func(*cobra.Command, []string) {
+// helpConfig controls how help output is rendered.
+type helpConfig struct {
+	theme               Theme
+	width               int
+	showHidden          bool
+	collapseGlobalFlags bool
+	banner              string
+	footer              string
+	sections            []Section
+	hiddenEnvDocs       bool
+	envSection          bool
+	enumLimit           int
+}
+
+// Section identifies a section of the help output that can be reordered
+// with [WithHelpSections].
+type Section int
+
+const (
+	// SectionDescription is the long, or short, description of a command.
+	SectionDescription Section = iota
+
+	// SectionUsage is the USAGE line, followed by any ALIASES.
+	SectionUsage
+
+	// SectionCommands lists the available subcommands, under a heading for
+	// each command group.
+	SectionCommands
+
+	// SectionExamples lists the examples of a command.
+	SectionExamples
+
+	// SectionFlags lists the local flags, including any flag groups.
+	SectionFlags
+
+	// SectionGlobalFlags lists the flags inherited from parent commands.
+	SectionGlobalFlags
+)
+
+var defaultHelpSections = []Section{
+	SectionDescription,
+	SectionUsage,
+	SectionCommands,
+	SectionExamples,
+	SectionFlags,
+	SectionGlobalFlags,
+}
+
+// shows reports whether an entry should be rendered, given if it is hidden.
+func (c helpConfig) shows(hidden bool) bool {
+	return !hidden || c.showHidden
+}
+
+func helpFunc(cfg helpConfig) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, _ []string) {
-		renderHelp(cmd.OutOrStdout(), cmd, theme, width)
+		if f := cmd.Flags().Lookup(helpAllFlag); f != nil && f.Changed {
+			renderHelpAll(cmd.OutOrStdout(), cmd, cfg)
+			return
+		}
+		renderHelp(cmd.OutOrStdout(), cmd, cfg)
 	}
 }
 
-func usageFunc(theme Theme, width int) func(*cobra.Command) error {
+func usageFunc(cfg helpConfig) func(*cobra.Command) error {
 	return func(cmd *cobra.Command) error {
-		renderHelp(cmd.OutOrStderr(), cmd, theme, width)
+		renderHelp(cmd.OutOrStderr(), cmd, cfg)
 		return nil
 	}
 }
 
-func renderHelp(w io.Writer, cmd *cobra.Command, theme Theme, width int) {
-	if desc := cmd.Long; desc != "" {
-		fmt.Fprintln(w, wrapText(dedent(desc), width))
+func renderHelp(w io.Writer, cmd *cobra.Command, cfg helpConfig) {
+	theme, width := cfg.theme, cfg.width
+
+	if banner := bannerLines(cfg.banner); len(banner) > 0 && !cmd.HasParent() {
+		// Banners are often ASCII art, so are truncated rather than wrapped
+		for _, line := range banner {
+			fmt.Fprintln(w, theme.Command.MaxWidth(width).Render(line))
+		}
 		fmt.Fprintln(w)
-	} else if desc := cmd.Short; desc != "" {
-		fmt.Fprintln(w, wrapText(dedent(desc), width))
+	}
+
+	if cmd.Deprecated != "" {
+		fmt.Fprintln(w, wrapText(theme.Header.Render("DEPRECATED:")+" "+cmd.Deprecated, width))
 		fmt.Fprintln(w)
 	}
 
-	fmt.Fprintln(w, theme.Header.Render("USAGE"))
-	fmt.Fprintln(w)
-	fmt.Fprintf(w, "  %s\n", formatUsage(cmd, theme))
+	sections := cfg.sections
+	if len(sections) == 0 {
+		sections = defaultHelpSections
+	}
 
-	if hasSubCommands(cmd) {
+	// Sections are separated by a blank line, which is dropped before the first
+	first := true
+	for _, section := range sections {
+		var b strings.Builder
+		renderSection(&b, cmd, section, cfg)
+		if b.Len() == 0 {
+			continue
+		}
+
+		out := b.String()
+		if first {
+			out = strings.TrimPrefix(out, "\n")
+			first = false
+		}
+		fmt.Fprint(w, out)
+	}
+
+	if bindings := envBindings(cmd, cfg); len(bindings) > 0 {
 		fmt.Fprintln(w)
-		fmt.Fprintln(w, theme.Header.Render("COMMANDS"))
+		fmt.Fprintln(w, theme.Header.Render("ENVIRONMENT"))
 		fmt.Fprintln(w)
-		renderCommands(w, cmd, theme, width)
+		renderEnvBindings(w, bindings, theme)
 	}
 
-	if cmd.Example != "" {
+	if related := relatedCommands(cmd); len(related) > 0 {
 		fmt.Fprintln(w)
-		fmt.Fprintln(w, theme.Header.Render("EXAMPLES"))
+		fmt.Fprintln(w, theme.Header.Render("SEE ALSO"))
 		fmt.Fprintln(w)
-		renderExamples(w, dedent(cmd.Example), cmd, theme)
+		renderCommandList(w, related, func(c *cobra.Command) string { return c.CommandPath() }, cfg)
+	}
+
+	if footer := strings.TrimSpace(dedent(cfg.footer)); footer != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, theme.Description.Render(wrapText(footer, width)))
+	}
+}
+
+const helpAllFlag = "help-all"
+
+// addHelpAllFlag adds the --help-all flag to cmd. Cobra prints help whenever
+// a command returns [pflag.ErrHelp], so the pre-run of a runnable command
+// returns it to defer to the help function.
+func addHelpAllFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().Bool(helpAllFlag, false, "help for this command and every command beneath it")
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		if helpAll, _ := c.Flags().GetBool(helpAllFlag); helpAll {
+			return pflag.ErrHelp
+		}
+		return nil
+	})
+}
+
+// renderHelpAll writes the help of cmd and every visible command beneath it,
+// depth-first, with each separated by a horizontal rule.
+func renderHelpAll(w io.Writer, cmd *cobra.Command, cfg helpConfig) {
+	rule := cfg.theme.Comment.Render(strings.Repeat("─", cfg.width))
+
+	first := true
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		if !first {
+			fmt.Fprintf(w, "\n%s\n\n", rule)
+		}
+		first = false
+
+		// Cobra only adds the help flag to the command being executed
+		c.InitDefaultHelpFlag()
+		renderHelp(w, c, cfg)
+		for _, sub := range visibleCommands(c, cfg.showHidden) {
+			walk(sub)
+		}
 	}
+	walk(cmd)
+}
 
-	if cmd.HasAvailableLocalFlags() {
-		renderGroupedFlags(w, cmd.LocalFlags(), "FLAGS", theme, width)
+// bannerLines unindents a banner and drops any surrounding blank lines. Unlike
+// dedent, the indentation of the first line is kept, as ASCII art relies on it.
+func bannerLines(s string) []string {
+	lines := strings.Split(unindent(s), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
 	}
+	return lines
+}
+
+// renderSection writes a single section of the help output, preceded by a
+// blank line. Nothing is written if the section has no content.
+func renderSection(w io.Writer, cmd *cobra.Command, section Section, cfg helpConfig) {
+	theme, width := cfg.theme, cfg.width
+
+	switch section {
+	case SectionDescription:
+		desc := cmd.Long
+		if desc == "" {
+			desc = cmd.Short
+		}
+		if desc != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, styleDescription(wrapText(dedent(desc), width), theme))
+		}
 
-	if cmd.HasAvailableInheritedFlags() && cmd.Annotations["hideInheritedFlags"] != "true" {
+	case SectionUsage:
 		fmt.Fprintln(w)
-		fmt.Fprintln(w, theme.Header.Render("GLOBAL FLAGS"))
+		fmt.Fprintln(w, theme.Header.Render("USAGE"))
 		fmt.Fprintln(w)
-		renderFlags(w, cmd.InheritedFlags(), theme, width)
+		fmt.Fprintf(w, "  %s\n", formatUsage(cmd, theme))
+
+		if len(cmd.Aliases) > 0 {
+			aliases := make([]string, len(cmd.Aliases))
+			for i, alias := range cmd.Aliases {
+				aliases[i] = theme.Command.Render(alias)
+			}
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, theme.Header.Render("ALIASES"))
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "  %s\n", strings.Join(aliases, ", "))
+		}
+
+	case SectionCommands:
+		renderCommands(w, cmd, cfg)
+
+	case SectionExamples:
+		if cmd.Example != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, theme.Header.Render("EXAMPLES"))
+			fmt.Fprintln(w)
+			renderExamples(w, dedent(cmd.Example), cmd, theme)
+		}
+
+	case SectionFlags:
+		if len(visibleFlags(cmd.LocalFlags(), cfg.showHidden)) > 0 {
+			renderGroupedFlags(w, cmd.LocalFlags(), "FLAGS", cfg)
+		}
+
+	case SectionGlobalFlags:
+		inherited := visibleFlags(cmd.InheritedFlags(), cfg.showHidden)
+		if len(inherited) == 0 || cmd.Annotations["hideInheritedFlags"] == "true" {
+			return
+		}
+
+		fmt.Fprintln(w)
+		if cfg.collapseGlobalFlags || cmd.Annotations["collapseInheritedFlags"] == "true" {
+			hint := fmt.Sprintf("Run '%s --help' to view %d global flags", cmd.Root().Name(), len(inherited))
+			fmt.Fprintf(w, "%s  %s\n", theme.Header.Render("GLOBAL FLAGS"), theme.Description.Render(hint))
+		} else {
+			fmt.Fprintln(w, theme.Header.Render("GLOBAL FLAGS"))
+			fmt.Fprintln(w)
+			renderFlagList(w, inherited, cfg)
+		}
+	}
+}
+
+// envBindings returns the flags available to cmd that should be listed within
+// the ENVIRONMENT section, as they are bound to an environment variable.
+func envBindings(cmd *cobra.Command, cfg helpConfig) []*pflag.Flag {
+	var bindings []*pflag.Flag
+
+	collect := func(f *pflag.Flag) {
+		if GetEnvVar(f) == "" {
+			return
+		}
+
+		if cfg.envSection || (f.Hidden && cfg.hiddenEnvDocs) {
+			bindings = append(bindings, f)
+		}
+	}
+	cmd.LocalFlags().VisitAll(collect)
+	cmd.InheritedFlags().VisitAll(collect)
+
+	return bindings
+}
+
+func renderEnvBindings(w io.Writer, flags []*pflag.Flag, theme Theme) {
+	maxLen := 0
+	for _, f := range flags {
+		maxLen = max(maxLen, len(GetEnvVar(f)))
+	}
+
+	for _, f := range flags {
+		envVar := GetEnvVar(f)
+		padding := strings.Repeat(" ", maxLen-len(envVar)+4)
+		fmt.Fprintf(w, "  %s%s%s %s\n",
+			theme.EnvVar.Render(envVar),
+			padding,
+			theme.Description.Render("maps to"),
+			theme.Flag.Render("--"+f.Name))
+	}
+}
+
+// relatedCommands resolves the commands marked as related to cmd, skipping
+// any that do not exist.
+func relatedCommands(cmd *cobra.Command) []*cobra.Command {
+	names, ok := cmd.Annotations[relatedAnnotation]
+	if !ok || names == "" {
+		return nil
 	}
+
+	var related []*cobra.Command
+	for name := range strings.SplitSeq(names, ",") {
+		path := strings.Fields(name)
+		if len(path) == 0 {
+			continue
+		}
+
+		found, rest, err := cmd.Root().Find(path)
+		if err != nil || len(rest) > 0 || found == cmd.Root() {
+			continue
+		}
+		related = append(related, found)
+	}
+	return related
 }
 
 type flagGroup struct {
@@ -68,13 +324,13 @@ type flagGroup struct {
 	flags []*pflag.Flag
 }
 
-func collectFlagGroups(flags *pflag.FlagSet) (ungrouped []*pflag.Flag, groups []flagGroup) {
+func collectFlagGroups(flags *pflag.FlagSet, showHidden bool) (ungrouped []*pflag.Flag, groups []flagGroup) {
 	groupOrder := make([]string, 0)
 	groupFlags := make(map[string][]*pflag.Flag)
 	seen := make(map[string]bool)
 
 	flags.VisitAll(func(f *pflag.Flag) {
-		if f.Hidden {
+		if f.Hidden && !showHidden {
 			return
 		}
 
@@ -97,21 +353,22 @@ func collectFlagGroups(flags *pflag.FlagSet) (ungrouped []*pflag.Flag, groups []
 	return ungrouped, groups
 }
 
-func renderGroupedFlags(w io.Writer, flags *pflag.FlagSet, defaultHeader string, theme Theme, width int) {
-	ungrouped, groups := collectFlagGroups(flags)
+func renderGroupedFlags(w io.Writer, flags *pflag.FlagSet, defaultHeader string, cfg helpConfig) {
+	theme := cfg.theme
+	ungrouped, groups := collectFlagGroups(flags, cfg.showHidden)
 
 	if len(ungrouped) > 0 {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, theme.Header.Render(defaultHeader))
 		fmt.Fprintln(w)
-		renderFlagList(w, ungrouped, theme, width)
+		renderFlagList(w, ungrouped, cfg)
 	}
 
 	for _, g := range groups {
 		fmt.Fprintln(w)
 		fmt.Fprintln(w, theme.Header.Render(strings.ToUpper(g.name)))
 		fmt.Fprintln(w)
-		renderFlagList(w, g.flags, theme, width)
+		renderFlagList(w, g.flags, cfg)
 	}
 }
 
@@ -123,7 +380,7 @@ func formatUsage(cmd *cobra.Command, theme Theme) string {
 		parts = append(parts, theme.FlagType.Render("[FLAGS]"))
 	}
 
-	if args := extractArgs(cmd.Use); args != "" {
+	if args := argsUsage(cmd); args != "" {
 		parts = append(parts, theme.FlagType.Render(args))
 	}
 
@@ -134,6 +391,13 @@ func formatUsage(cmd *cobra.Command, theme Theme) string {
 	return strings.Join(parts, " ")
 }
 
+func argsUsage(cmd *cobra.Command) string {
+	if args, ok := cmd.Annotations[argsUsageAnnotation]; ok {
+		return args
+	}
+	return extractArgs(cmd.Use)
+}
+
 func extractArgs(use string) string {
 	parts := strings.SplitN(use, " ", 2)
 	if len(parts) > 1 {
@@ -143,36 +407,105 @@ func extractArgs(use string) string {
 }
 
 func hasSubCommands(cmd *cobra.Command) bool {
+	return len(visibleCommands(cmd, false)) > 0
+}
+
+// visibleCommands returns the subcommands of cmd to render, optionally
+// including hidden commands. Unnamed commands, such as the placeholder help
+// command, are always skipped.
+func visibleCommands(cmd *cobra.Command, showHidden bool) []*cobra.Command {
+	var visible []*cobra.Command
 	for _, sub := range cmd.Commands() {
-		if !sub.Hidden {
-			return true
+		if sub.Name() != "" && (!sub.Hidden || showHidden) {
+			visible = append(visible, sub)
 		}
 	}
-	return false
+	return visible
 }
 
-func renderCommands(w io.Writer, cmd *cobra.Command, theme Theme, width int) {
+type commandGroup struct {
+	title    string
+	commands []*cobra.Command
+}
+
+// collectCommandGroups splits the visible subcommands of cmd by the groups
+// added through cobra's AddGroup, in the order the groups were added. Groups
+// without a visible command are dropped.
+func collectCommandGroups(cmd *cobra.Command, showHidden bool) (ungrouped []*cobra.Command, groups []commandGroup) {
+	index := make(map[string]int)
+	for _, g := range cmd.Groups() {
+		index[g.ID] = len(groups)
+		groups = append(groups, commandGroup{title: g.Title})
+	}
+
+	for _, sub := range visibleCommands(cmd, showHidden) {
+		if i, ok := index[sub.GroupID]; ok {
+			groups[i].commands = append(groups[i].commands, sub)
+		} else {
+			ungrouped = append(ungrouped, sub)
+		}
+	}
+
+	nonEmpty := groups[:0]
+	for _, g := range groups {
+		if len(g.commands) > 0 {
+			nonEmpty = append(nonEmpty, g)
+		}
+	}
+	return ungrouped, nonEmpty
+}
+
+func renderCommands(w io.Writer, cmd *cobra.Command, cfg helpConfig) {
+	theme := cfg.theme
+	ungrouped, groups := collectCommandGroups(cmd, cfg.showHidden)
+
+	for _, g := range groups {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, theme.Header.Render(strings.ToUpper(strings.TrimSuffix(g.title, ":"))))
+		fmt.Fprintln(w)
+		renderCommandList(w, g.commands, (*cobra.Command).Name, cfg)
+	}
+
+	if len(ungrouped) > 0 {
+		header := "COMMANDS"
+		if len(groups) > 0 {
+			header = "ADDITIONAL COMMANDS"
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, theme.Header.Render(header))
+		fmt.Fprintln(w)
+		renderCommandList(w, ungrouped, (*cobra.Command).Name, cfg)
+	}
+}
+
+func renderCommandList(w io.Writer, subs []*cobra.Command, nameOf func(*cobra.Command) string, cfg helpConfig) {
+	theme, width := cfg.theme, cfg.width
+
 	maxLen := 0
-	for _, sub := range cmd.Commands() {
-		if !sub.Hidden && len(sub.Name()) > maxLen {
-			maxLen = len(sub.Name())
+	for _, sub := range subs {
+		if len(nameOf(sub)) > maxLen {
+			maxLen = len(nameOf(sub))
 		}
 	}
 
 	indent := 2 + maxLen + 4
 
-	for _, sub := range cmd.Commands() {
-		if sub.Hidden {
-			continue
-		}
-		padding := strings.Repeat(" ", maxLen-len(sub.Name())+4)
-		name := theme.Command.Render(sub.Name())
+	for _, sub := range subs {
+		padding := strings.Repeat(" ", maxLen-len(nameOf(sub))+4)
+		name := theme.Command.Render(nameOf(sub))
 
 		descWidth := width - indent
 		if descWidth <= 0 || width == 0 {
 			descWidth = 0
 		}
-		wrapped := wrapText(sub.Short, descWidth)
+		short := sub.Short
+		if len(sub.Aliases) > 0 {
+			short += " (aliases: " + strings.Join(sub.Aliases, ", ") + ")"
+		}
+		if sub.Deprecated != "" {
+			short += " (deprecated)"
+		}
+		wrapped := wrapText(short, descWidth)
 		lines := strings.Split(wrapped, "\n")
 
 		desc := theme.Description.Render(lines[0])
@@ -245,19 +578,72 @@ func formatDefaultValue(value, valueType string, style lipgloss.Style) string {
 	}
 }
 
-func renderFlags(w io.Writer, flags *pflag.FlagSet, theme Theme, width int) {
-	var flagList []*pflag.Flag
+// flagDefault returns the default value of a flag along with the type used to
+// format it, reporting false if the default is a zero value not worth showing
+// or belongs to a secret flag.
+func flagDefault(f *pflag.Flag) (string, string, bool) {
+	if IsFlagSecret(f) {
+		return "", "", false
+	}
+
+	defValue, valueType := f.DefValue, f.Value.Type()
+	hasDefault := defValue != "" && defValue != "false" && defValue != "0" && defValue != "[]"
+	if helper, ok := f.Value.(EnumHelper); ok {
+		valueType = helper.BaseType()
+		if namer, ok := f.Value.(EnumDefaultNamer); ok {
+			defValue = namer.DefaultName()
+			hasDefault = defValue != ""
+		}
+	}
+	return defValue, valueType, hasDefault
+}
+
+// visibleFlags returns the flags within a set to render, optionally including
+// hidden flags.
+func visibleFlags(flags *pflag.FlagSet, showHidden bool) []*pflag.Flag {
+	var visible []*pflag.Flag
 	flags.VisitAll(func(f *pflag.Flag) {
-		if !f.Hidden {
-			flagList = append(flagList, f)
+		if !f.Hidden || showHidden {
+			visible = append(visible, f)
 		}
 	})
-	renderFlagList(w, flagList, theme, width)
+	return visible
+}
+
+// flagPlaceholder returns the placeholder for the value of a flag, reporting
+// whether it lists the allowed values of an enum. Enums without help list
+// their allowed values inline, otherwise the placeholder names the type of
+// value expected.
+func flagPlaceholder(f *pflag.Flag, enumLimit int) (string, bool) {
+	helper, ok := f.Value.(EnumHelper)
+	if !ok {
+		return flagTypeName(f.Value.Type()), false
+	}
+	if helper.HasHelp() {
+		return flagTypeName(helper.BaseType()), false
+	}
+	return enumPlaceholder(helper, enumLimit), true
 }
 
-func formatEnvVar(envVar string, theme Theme) string {
+// enumPlaceholder joins the allowed values of an enum, truncating them with
+// an ellipsis beyond limit. A limit of 0 or less lists every value.
+func enumPlaceholder(helper EnumHelper, limit int) string {
+	entries := helper.HelpEntries()
+
+	names := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		if limit > 0 && i == limit {
+			names = append(names, "...")
+			break
+		}
+		names = append(names, entry.Name)
+	}
+	return strings.Join(names, "|")
+}
+
+func formatEnvVar(envVar string, secret bool, theme Theme) string {
 	val := os.Getenv(envVar)
-	if val == "" {
+	if val == "" || secret {
 		return "[env: " + theme.EnvVar.Render(envVar) + "]"
 	}
 
@@ -267,7 +653,8 @@ func formatEnvVar(envVar string, theme Theme) string {
 	return "[env: " + theme.EnvVar.Render(envVar) + "=" + theme.EnvVarValue.Render(val) + "]"
 }
 
-func renderFlagList(w io.Writer, flags []*pflag.Flag, theme Theme, width int) {
+func renderFlagList(w io.Writer, flags []*pflag.Flag, cfg helpConfig) {
+	theme, width := cfg.theme, cfg.width
 	const flagIndent = 10
 
 	for i, f := range flags {
@@ -282,16 +669,17 @@ func renderFlagList(w io.Writer, flags []*pflag.Flag, theme Theme, width int) {
 			flagStr = fmt.Sprintf("    --%s", f.Name)
 		}
 
-		flagType := f.Value.Type()
-		if flagType != "bool" {
-			if helper, ok := f.Value.(EnumHelper); ok && helper.HasHelp() {
-				flagType = helper.BaseType()
+		if f.Value.Type() != "bool" {
+			argStyle := theme.FlagArg
+			placeholder, enumValues := flagPlaceholder(f, cfg.enumLimit)
+			if enumValues {
+				argStyle = theme.FlagType
 			}
-			flagStr += " " + theme.FlagType.Render(fmt.Sprintf("<%s>", flagTypeName(flagType)))
+			flagStr += " " + argStyle.Render(fmt.Sprintf("<%s>", placeholder))
 		}
 
 		if envVar := GetEnvVar(f); envVar != "" {
-			flagStr += "  " + formatEnvVar(envVar, theme)
+			flagStr += "  " + formatEnvVar(envVar, IsFlagSecret(f), theme)
 		}
 
 		fmt.Fprintf(w, "  %s\n", theme.Flag.Render(flagStr))
@@ -302,68 +690,145 @@ func renderFlagList(w io.Writer, flags []*pflag.Flag, theme Theme, width int) {
 		}
 
 		desc := f.Usage
-		hasDefault := f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" && f.DefValue != "[]"
+		if defValue, valueType, ok := flagDefault(f); ok {
+			formatted := formatDefaultValue(defValue, valueType, theme.FlagDefault)
+			desc += " (default: " + formatted + ")"
+		}
 
 		wrapped := wrapText(desc, descWidth)
-		lines := strings.Split(wrapped, "\n")
+		for line := range strings.SplitSeq(wrapped, "\n") {
+			fmt.Fprintf(w, "          %s\n", theme.Description.Render(line))
+		}
 
-		for j, line := range lines {
-			isLastLine := j == len(lines)-1
-			if isLastLine && hasDefault {
-				valueType := f.Value.Type()
-				if helper, ok := f.Value.(EnumHelper); ok {
-					valueType = helper.BaseType()
-				}
-				formatted := formatDefaultValue(f.DefValue, valueType, theme.FlagDefault)
-				line = line + " (default: " + formatted + ")"
+		if requires := GetFlagRequires(f); len(requires) > 0 {
+			names := make([]string, len(requires))
+			for i, name := range requires {
+				names[i] = theme.FlagDefault.Render("--" + name)
+			}
+			fmt.Fprintf(w, "          [requires: %s]\n", strings.Join(names, ", "))
+		}
+
+		if f.Deprecated != "" {
+			note := wrapText("[deprecated: "+f.Deprecated+"]", descWidth)
+			for line := range strings.SplitSeq(note, "\n") {
+				fmt.Fprintf(w, "          %s\n", theme.Description.Render(line))
 			}
-			fmt.Fprintf(w, "          %s\n", theme.Description.Render(line))
 		}
 
 		if helper, ok := f.Value.(EnumHelper); ok && helper.HasHelp() {
 			fmt.Fprintln(w)
 			fmt.Fprintf(w, "          %s\n", theme.Description.Render("Possible values:"))
 			for _, entry := range helper.HelpEntries() {
-				if entry.Help != "" {
-					fmt.Fprintf(w, "          - %s: %s\n",
-						theme.FlagType.Render(entry.Name),
-						theme.Description.Render(entry.Help))
-				} else {
+				if entry.Help == "" {
 					fmt.Fprintf(w, "          - %s\n", theme.FlagType.Render(entry.Name))
+					continue
+				}
+
+				// Continuation lines hang beneath the start of the help text
+				hang := len(entry.Name) + 4
+				helpWidth := descWidth - hang
+				if helpWidth <= 0 || descWidth == 0 {
+					helpWidth = 0
+				}
+				lines := strings.Split(wrapText(entry.Help, helpWidth), "\n")
+
+				fmt.Fprintf(w, "          - %s: %s\n",
+					theme.FlagType.Render(entry.Name),
+					theme.Description.Render(lines[0]))
+				for _, line := range lines[1:] {
+					fmt.Fprintf(w, "          %s%s\n", strings.Repeat(" ", hang), theme.Description.Render(line))
 				}
 			}
 		}
 	}
 }
 
-func renderExamples(w io.Writer, s string, cmd *cobra.Command, theme Theme) {
-	subcommands := make(map[string]bool)
-	root := cmd.Root()
-	for _, c := range root.Commands() {
-		if !c.Hidden {
-			subcommands[c.Name()] = true
+// descriptionPattern matches, in order of precedence, regions already styled
+// by the author, backtick-wrapped code spans and references to long flags.
+// Flags must follow whitespace or an opening bracket or quote, so the dashes
+// within URLs and hyphenated words are never matched.
+var descriptionPattern = regexp.MustCompile("(?s)(\x1b\\[[0-9;]*m.*?\x1b\\[0m)|(`[^`]+`)|((?:^|[\\s(\\[\"'])--[a-zA-Z0-9][\\w-]*)")
+
+// styleDescription styles code spans and flag references within the
+// description of a command.
+func styleDescription(s string, theme Theme) string {
+	return descriptionPattern.ReplaceAllStringFunc(s, func(match string) string {
+		switch {
+		case strings.HasPrefix(match, "\x1b"):
+			return match
+		case strings.HasPrefix(match, "`"):
+			return theme.Code.Render(match)
 		}
-	}
+
+		// Keep the character preceding the flag unstyled
+		if match[0] != '-' {
+			return match[:1] + theme.Flag.Render(match[1:])
+		}
+		return theme.Flag.Render(match)
+	})
+}
+
+func renderExamples(w io.Writer, s string, cmd *cobra.Command, theme Theme) {
+	state := exampleState{root: cmd.Root(), expectCommand: true}
 
 	for line := range strings.SplitSeq(s, "\n") {
-		if line == "" {
+		switch {
+		case line == "" && state.quote == 0:
 			fmt.Fprintln(w)
-			continue
-		}
-		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		case strings.HasPrefix(strings.TrimSpace(line), "#") && state.quote == 0 && state.heredoc == "":
 			fmt.Fprintf(w, "  %s\n", theme.Comment.Render(line))
-		} else {
-			styled := styleExampleLine(line, root.Name(), subcommands, theme)
-			fmt.Fprintf(w, "  %s\n", styled)
+		default:
+			fmt.Fprintf(w, "  %s\n", styleExampleLine(line, &state, theme))
 		}
 	}
 }
 
-func styleExampleLine(line, rootCmd string, subcommands map[string]bool, theme Theme) string {
-	tokens := tokenizeExample(line)
+// exampleState tracks the styling of an example across lines, allowing a
+// command to continue onto the next line.
+type exampleState struct {
+	root *cobra.Command
+	// command is the last command matched within the command path, used to
+	// identify the next subcommand
+	command       *cobra.Command
+	expectCommand bool
+	continued     bool
+	// quote is the quote of a string left open at the end of the last line
+	quote rune
+	// heredoc is the delimiter of a heredoc whose body is being rendered
+	heredoc string
+}
+
+func styleExampleLine(line string, state *exampleState, theme Theme) string {
+	// The body of a heredoc is left untouched
+	if state.heredoc != "" {
+		if strings.TrimSpace(line) == state.heredoc {
+			state.heredoc = ""
+			state.expectCommand = true
+		}
+		return line
+	}
+
 	var result strings.Builder
 
-	expectCommand := true
+	// A string left open by the last line is left untouched until closed
+	if state.quote != 0 {
+		end := strings.IndexRune(line, state.quote)
+		if end == -1 {
+			return line
+		}
+		result.WriteString(line[:end+1])
+		line = line[end+1:]
+		state.quote = 0
+	}
+
+	if !state.continued {
+		state.expectCommand = true
+		state.command = nil
+	}
+	state.continued = false
+
+	tokens := tokenizeExample(line)
+	expectHeredoc := false
 	for _, token := range tokens {
 		switch token.tokenType {
 		case tokenWhitespace:
@@ -373,11 +838,21 @@ func styleExampleLine(line, rootCmd string, subcommands map[string]bool, theme T
 			result.WriteString(theme.Operator.Render(token.value))
 			// After a pipe or semicolon, the next word is a command
 			if token.value == "|" || token.value == ";" || token.value == "&&" || token.value == "||" {
-				expectCommand = true
+				state.expectCommand = true
+				state.command = nil
 			}
+			expectHeredoc = token.value == "<<"
 
 		case tokenString:
 			result.WriteString(token.value)
+			if unterminatedString(token.value) {
+				state.quote = rune(token.value[0])
+				state.continued = true
+			}
+			if expectHeredoc {
+				state.heredoc = strings.Trim(token.value, `"'`)
+				expectHeredoc = false
+			}
 
 		case tokenEnvAssign:
 			// Style environment variable name and value separately
@@ -393,18 +868,28 @@ func styleExampleLine(line, rootCmd string, subcommands map[string]bool, theme T
 
 		case tokenLineContinuation:
 			result.WriteString(theme.Operator.Render(token.value))
+			state.continued = true
 
 		case tokenWord:
-			switch {
-			case expectCommand && token.value == rootCmd:
-				result.WriteString(theme.Command.Render(token.value))
-				expectCommand = false
-			case subcommands[token.value]:
+			if expectHeredoc {
+				state.heredoc = strings.Trim(strings.TrimPrefix(token.value, "-"), `"'`)
+				expectHeredoc = false
+				result.WriteString(token.value)
+				continue
+			}
+
+			switch sub := findSubcommand(state.command, token.value); {
+			case sub != nil:
 				result.WriteString(theme.Command.Render(token.value))
-				expectCommand = false
-			case expectCommand:
+				state.command = sub
+				state.expectCommand = false
+			case state.expectCommand:
 				result.WriteString(theme.Command.Render(token.value))
-				expectCommand = false
+				state.command = nil
+				if token.value == state.root.Name() {
+					state.command = state.root
+				}
+				state.expectCommand = false
 			case strings.HasPrefix(token.value, "-"):
 				if idx := strings.Index(token.value, "="); idx != -1 {
 					flag := token.value[:idx+1]
@@ -423,6 +908,35 @@ func styleExampleLine(line, rootCmd string, subcommands map[string]bool, theme T
 	return result.String()
 }
 
+// findSubcommand returns the visible subcommand of cmd with the given name or
+// alias, or nil if there is no match.
+func findSubcommand(cmd *cobra.Command, name string) *cobra.Command {
+	if cmd == nil {
+		return nil
+	}
+
+	for _, sub := range cmd.Commands() {
+		if !sub.Hidden && (sub.Name() == name || sub.HasAlias(name)) {
+			return sub
+		}
+	}
+	return nil
+}
+
+// unterminatedString reports whether a quoted string token runs to the end of
+// the line without being closed.
+func unterminatedString(s string) bool {
+	if len(s) < 2 || s[len(s)-1] != s[0] {
+		return true
+	}
+	// A double quote preceded by an odd number of backslashes is escaped
+	if s[0] == '"' {
+		escapes := len(s[:len(s)-1]) - len(strings.TrimRight(s[:len(s)-1], "\\"))
+		return escapes%2 == 1
+	}
+	return false
+}
+
 // tokenType represents the type of token in an example line.
 type tokenType int
 
@@ -470,7 +984,8 @@ func tokenizeExample(line string) []exampleToken {
 			start := i
 			i++
 			for i < len(runes) && runes[i] != quote {
-				if runes[i] == '\\' && i+1 < len(runes) {
+				// Backslashes are only escapes within double quotes
+				if quote == '"' && runes[i] == '\\' && i+1 < len(runes) {
 					i += 2
 				} else {
 					i++
@@ -2,9 +2,15 @@ package cli
 
 import (
 	"bytes"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gotest.tools/v3/golden"
 )
@@ -19,6 +25,19 @@ const (
 	LogError LogLevel = "error"
 )
 
+// GPG trust level enum with human readable display names
+type trustLevel int
+
+const (
+	trustUnknown trustLevel = iota
+	trustNever
+	trustMarginal
+)
+
+func (t trustLevel) String() string {
+	return [...]string{"unknown", "never", "marginal"}[t]
+}
+
 // use a realistic example based on: https://github.com/purpleclay/nsv
 func newRootCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -92,6 +111,28 @@ func newVersionCmd() *cobra.Command {
 	}
 }
 
+// newStyledTheme returns a theme bound to a renderer with a fixed color
+// profile, ensuring styled output is deterministic regardless of terminal.
+func newStyledTheme() Theme {
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(termenv.ANSI)
+
+	return Theme{
+		Code:        r.NewStyle().Foreground(lipgloss.Color("6")),
+		Command:     r.NewStyle().Foreground(lipgloss.Color("5")),
+		Comment:     r.NewStyle().Foreground(lipgloss.Color("2")),
+		Description: r.NewStyle(),
+		EnvVar:      r.NewStyle().Foreground(lipgloss.Color("4")),
+		EnvVarValue: r.NewStyle().Foreground(lipgloss.Color("6")),
+		Flag:        r.NewStyle().Bold(true).Foreground(lipgloss.Color("3")),
+		FlagArg:     r.NewStyle().Foreground(lipgloss.Color("6")),
+		FlagDefault: r.NewStyle().Foreground(lipgloss.Color("5")),
+		FlagType:    r.NewStyle().Foreground(lipgloss.Color("5")),
+		Header:      r.NewStyle().Bold(true),
+		Operator:    r.NewStyle().Foreground(lipgloss.Color("1")),
+	}
+}
+
 func TestHelp(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -105,6 +146,110 @@ func TestHelp(t *testing.T) {
 	golden.Assert(t, buf.String(), "help.golden")
 }
 
+func TestHelpWithTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd(), newVersionCmd())
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_theme.golden")
+}
+
+func TestHelpWithThemeFlagArgs(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use:   "gpg-import",
+		Short: "Import your GPG private key into the local keyring",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+
+	cmd.Flags().StringP("key", "k", "", "a base64 encoded GPG private key")
+	cmd.Flags().VarP(Enum(trustUnknown, trustUnknown, trustNever, trustMarginal), "trust-level", "t", "a level of trust to associate with the GPG private key")
+	cmd.SetArgs([]string{"--help"})
+
+	err := Execute(cmd, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_theme_flag_args.golden")
+}
+
+func TestHelpWithThemeAndNoTerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd(), newVersionCmd())
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help.golden")
+}
+
+func TestHelpWithThemeColorEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		noColor    string
+		forceColor string
+		styled     bool
+	}{
+		{name: "ForceColor", forceColor: "1", styled: true},
+		{name: "NoColor", noColor: "1", styled: false},
+		{name: "NoColorTakesPrecedence", noColor: "1", forceColor: "1", styled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("FORCE_COLOR", tt.forceColor)
+
+			var buf bytes.Buffer
+
+			// Styles bound to lipgloss's default renderer, as used by the
+			// theme package, detect a non-terminal and need forcing
+			theme := DefaultTheme()
+			theme.Header = lipgloss.NewStyle().Bold(true)
+
+			root := newRootCmd()
+			root.SetArgs([]string{"--help"})
+
+			err := Execute(root, WithStdout(&buf), WithTheme(theme))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.styled, strings.Contains(buf.String(), "\x1b["))
+		})
+	}
+}
+
+func TestHelpWithThemeAndNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd(), newVersionCmd())
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help.golden")
+}
+
 func TestHelpWithExamples(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -118,6 +263,127 @@ func TestHelpWithExamples(t *testing.T) {
 	golden.Assert(t, buf.String(), "help_with_examples.golden")
 }
 
+func TestHelpWithStyledExamples(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(&cobra.Command{
+		Use:   "changelog",
+		Short: "Generate a changelog from conventional commits",
+		Example: `
+			# Write the changelog for the next release to a file
+			NSV_FORMAT=v{{.Version}} nsv changelog | tee CHANGELOG.md
+
+			# Operators within quotes are left untouched
+			nsv changelog --title "fixes | features && more" > notes.md
+		`,
+		Run: func(_ *cobra.Command, _ []string) {},
+	})
+	root.SetArgs([]string{"changelog", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_styled_examples.golden")
+}
+
+func TestHelpWithStyledDescription(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(&cobra.Command{
+		Use:   "changelog",
+		Short: "Generate a changelog from conventional commits",
+		Long: `
+			Generate a changelog from conventional commits. Use --format to change
+			the output, or pipe it to ` + "`tee CHANGELOG.md`" + ` to write it to a file.
+
+			Further details at https://docs.purpleclay.dev/nsv--changelog, where
+			custom runners (--runner) are covered.
+		`,
+		Run: func(_ *cobra.Command, _ []string) {},
+	})
+	root.SetArgs([]string{"changelog", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_styled_description.golden")
+}
+
+func TestStyleDescription(t *testing.T) {
+	theme := newStyledTheme()
+	flag := theme.Flag.Render("--format")
+	code := theme.Code.Render("`nsv tag`")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Flag", input: "use --format", expected: "use " + flag},
+		{name: "FlagInBrackets", input: "output (--format)", expected: "output (" + flag + ")"},
+		{name: "Code", input: "run `nsv tag`", expected: "run " + code},
+		{name: "FlagInCode", input: "`nsv --format`", expected: theme.Code.Render("`nsv --format`")},
+		{name: "URL", input: "see https://example.com/a--format", expected: "see https://example.com/a--format"},
+		{name: "HyphenatedWord", input: "a well--known value", expected: "a well--known value"},
+		{name: "AlreadyStyled", input: "use " + flag + " with `code`", expected: "use " + flag + " with " + theme.Code.Render("`code`")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, styleDescription(tt.input, theme))
+		})
+	}
+}
+
+func TestHelpWithMultilineStyledExamples(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	next := &cobra.Command{
+		Use:   "next",
+		Short: "Generate the next semantic version",
+		Example: `
+			# Flags on continued lines are not mistaken for commands
+			nsv next \
+			  --show \
+			  --format 'v{{.Version}} | next'
+
+			# Quoted text spanning lines is left untouched
+			nsv next --message 'first line
+			nsv next --show' | tee next.txt
+
+			# The body of a heredoc is left untouched
+			cat <<EOF | nsv next config
+			nsv next --show
+			EOF
+		`,
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	next.AddCommand(&cobra.Command{
+		Use:   "config",
+		Short: "Read the configuration from stdin",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	})
+	root.AddCommand(next)
+	root.SetArgs([]string{"next", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_multiline_styled_examples.golden")
+}
+
 func TestHelpWithGlobalFlags(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -131,6 +397,308 @@ func TestHelpWithGlobalFlags(t *testing.T) {
 	golden.Assert(t, buf.String(), "help_with_global_flags.golden")
 }
 
+func TestHelpWithCollapsedGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation bool
+		opts       []Option
+		golden     string
+	}{
+		{name: "Option", opts: []Option{WithCollapsedGlobalFlags()}, golden: "help_with_collapsed_global_flags.golden"},
+		{name: "Annotation", annotation: true, golden: "help_with_collapsed_global_flags.golden"},
+		{name: "Expanded", golden: "help_with_global_flags.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			tag := newTagCmd()
+			if tt.annotation {
+				tag.Annotations = map[string]string{"collapseInheritedFlags": "true"}
+			}
+			root.AddCommand(tag)
+			root.SetArgs([]string{"tag", "--help"})
+
+			err := Execute(root, append([]Option{WithStdout(&buf)}, tt.opts...)...)
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithArgsUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		use      string
+		args     *string
+		expected string
+	}{
+		{name: "FromUse", use: "deploy ENV", expected: "deploy [FLAGS] ENV"},
+		{name: "Override", use: "deploy [--dry-run] ENV", args: ptr("<ENV>"), expected: "deploy [FLAGS] <ENV>"},
+		{name: "OverrideEmpty", use: "deploy [--dry-run]", args: ptr(""), expected: "deploy [FLAGS]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := &cobra.Command{
+				Use: tt.use,
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			if tt.args != nil {
+				ArgsUsage(cmd, *tt.args)
+			}
+			cmd.SetArgs([]string{"--help"})
+
+			err := Execute(cmd, WithStdout(&buf))
+			require.NoError(t, err)
+
+			assert.Contains(t, buf.String(), "USAGE\n\n  "+tt.expected+"\n")
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestHelpWithCommandSorting(t *testing.T) {
+	tests := []struct {
+		name     string
+		sorted   bool
+		expected string
+	}{
+		{name: "Sorted", sorted: true, expected: "  next       Generate the next semantic version\n  tag        Tag"},
+		{name: "InsertionOrder", sorted: false, expected: "  tag        Tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.AddCommand(newTagCmd(), newVersionCmd(), newNextCmd())
+			root.SetArgs([]string{"--help"})
+
+			err := Execute(root, WithStdout(&buf), WithCommandSorting(tt.sorted))
+			require.NoError(t, err)
+
+			assert.Contains(t, buf.String(), "COMMANDS\n\n"+tt.expected)
+			assert.True(t, cobra.EnableCommandSorting, "global setting should be restored")
+		})
+	}
+}
+
+func TestHelpWithRelatedCommands(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	next := newNextCmd()
+	MarkRelated(next, "tag", "version", "release")
+	root.AddCommand(next, newTagCmd(), newVersionCmd())
+	root.SetArgs([]string{"next", "--help"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_related_commands.golden")
+}
+
+func TestHelpWithFooter(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{name: "Root", args: []string{"--help"}, golden: "help_with_footer.golden"},
+		{name: "Subcommand", args: []string{"tag", "--help"}, golden: "help_with_footer_subcommand.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.AddCommand(newTagCmd())
+			root.SetArgs(tt.args)
+
+			err := Execute(root, WithStdout(&buf), WithHelpFooter(`
+				Learn more about NSV and conventional commits at
+				https://docs.purpleclay.dev/nsv
+			`))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithBanner(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{name: "Root", args: []string{"--help"}, golden: "help_with_banner.golden"},
+		{name: "Subcommand", args: []string{"tag", "--help"}, golden: "help_with_banner_subcommand.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", "")
+			t.Setenv("FORCE_COLOR", "1")
+
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.AddCommand(newTagCmd())
+			root.SetArgs(tt.args)
+
+			err := Execute(root, WithStdout(&buf), WithTheme(newStyledTheme()), WithBanner(`
+				 _ __  _____   __
+				| '_ \/ __\ \ / /
+				| | | \__ \\ V /
+				|_| |_|___/ \_/     semantic versioning without any config, driven entirely by your commits
+			`))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithEmptyBanner(t *testing.T) {
+	var withBanner, withoutBanner bytes.Buffer
+
+	err := Execute(newRootCmd(), WithStdout(&withBanner), WithBanner(""), WithArgs("--help"))
+	require.NoError(t, err)
+
+	err = Execute(newRootCmd(), WithStdout(&withoutBanner), WithArgs("--help"))
+	require.NoError(t, err)
+
+	assert.Equal(t, withoutBanner.String(), withBanner.String())
+}
+
+func TestHelpAll(t *testing.T) {
+	tests := []struct {
+		name string
+		runE bool
+	}{
+		{name: "Runnable", runE: true},
+		{name: "NotRunnable", runE: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ran := false
+
+			root := newRootCmd()
+			root.Run = nil
+			if tt.runE {
+				root.RunE = func(_ *cobra.Command, _ []string) error {
+					ran = true
+					return nil
+				}
+			}
+			root.AddCommand(newNextCmd(), newTagCmd())
+
+			err := Execute(root, WithStdout(&buf), WithHelpAll(), WithArgs("--help-all"))
+			require.NoError(t, err)
+			assert.False(t, ran)
+
+			golden.Assert(t, buf.String(), "help_all.golden")
+		})
+	}
+}
+
+func TestHelpAllSubcommand(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd())
+
+	err := Execute(root, WithStdout(&buf), WithHelpAll(), WithArgs("tag", "--help-all"))
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "Generate the next semantic version")
+	assert.Contains(t, buf.String(), "Tag the repository with the next semantic version")
+}
+
+func TestHelpWithSections(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd(), newVersionCmd())
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithHelpSections(
+		SectionUsage,
+		SectionFlags,
+		SectionCommands,
+		SectionDescription,
+	))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_sections.golden")
+}
+
+func TestHelpWithEnvSection(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.PersistentFlags().String("token", "", "token used to authenticate with the API")
+	BindEnv(root.PersistentFlags().Lookup("token"), "NSV_TOKEN")
+
+	tag := newTagCmd()
+	tag.Flags().Bool("sign", false, "sign the tag using GPG")
+	BindEnv(tag.Flags().Lookup("sign"), "NSV_SIGN")
+	root.AddCommand(tag)
+	root.SetArgs([]string{"tag", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithEnvSection())
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_env_section.golden")
+}
+
+func TestHelpWithEnvSectionNoBindings(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf), WithEnvSection())
+	require.NoError(t, err)
+
+	assert.NotContains(t, buf.String(), "ENVIRONMENT")
+}
+
+func TestHelpWithHiddenEnvDocs(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.PersistentFlags().String("api-token", "", "token used to authenticate with the API")
+	require.NoError(t, root.PersistentFlags().MarkHidden("api-token"))
+	BindEnv(root.PersistentFlags().Lookup("api-token"), "NSV_API_TOKEN")
+
+	tag := newTagCmd()
+	tag.Flags().Bool("sign", false, "sign the tag using GPG")
+	require.NoError(t, tag.Flags().MarkHidden("sign"))
+	BindEnv(tag.Flags().Lookup("sign"), "NSV_SIGN")
+	root.AddCommand(tag)
+	root.SetArgs([]string{"tag", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithHiddenEnvDocs())
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_hidden_env_docs.golden")
+}
+
 func TestHelpWithSubcommands(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -151,12 +719,128 @@ func TestHelpWithNoWrapping(t *testing.T) {
 	root.AddCommand(newNextCmd(), newTagCmd(), newVersionCmd())
 	root.SetArgs([]string{"--help"})
 
-	err := Execute(root, WithStdout(&buf), WithWidth(0))
+	err := Execute(root, WithStdout(&buf), WithHelpWidth(0))
 	require.NoError(t, err)
 
 	golden.Assert(t, buf.String(), "help_no_wrapping.golden")
 }
 
+func TestHelpWithHelpWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		golden string
+	}{
+		{name: "Narrow", width: 40, golden: "help_width_40.golden"},
+		{name: "Default", width: 80, golden: "help_width_80.golden"},
+		{name: "Wide", width: 120, golden: "help_width_120.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.AddCommand(newNextCmd())
+			root.SetArgs([]string{"next", "--help"})
+
+			err := Execute(root, WithStdout(&buf), WithHelpWidth(tt.width))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpCommandsWithWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		golden string
+	}{
+		{name: "Narrow", width: 50, golden: "help_commands_width_50.golden"},
+		{name: "Wide", width: 120, golden: "help_commands_width_120.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.AddCommand(newNextCmd(), newVersionCmd(), &cobra.Command{
+				Use:   "changelog",
+				Short: "Generate a changelog from every conventional commit since the last tag, grouped by type",
+				Run:   func(_ *cobra.Command, _ []string) {},
+			})
+			root.SetArgs([]string{"--help"})
+
+			err := Execute(root, WithStdout(&buf), WithHelpWidth(tt.width))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithCommandGroups(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddGroup(
+		&cobra.Group{ID: "release", Title: "Release Commands:"},
+		&cobra.Group{ID: "inspect", Title: "Inspection Commands:"},
+	)
+
+	next := newNextCmd()
+	next.GroupID = "release"
+	tag := newTagCmd()
+	tag.GroupID = "release"
+	root.AddCommand(next, tag, &cobra.Command{
+		Use:     "log",
+		Short:   "Show the commits since the last tag",
+		GroupID: "inspect",
+		Run:     func(_ *cobra.Command, _ []string) {},
+	}, newVersionCmd())
+	root.SetArgs([]string{"--help"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_command_groups.golden")
+}
+
+func TestHelpEnumPlaceholder(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		golden string
+	}{
+		{name: "Default", golden: "help_enum_placeholder.golden"},
+		{name: "WithLimit", opts: []Option{WithEnumLimit(3)}, golden: "help_enum_placeholder_limit.golden"},
+		{name: "Unlimited", opts: []Option{WithEnumLimit(0)}, golden: "help_enum_placeholder_unlimited.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := &cobra.Command{
+				Use:   "fmt",
+				Short: "Format the source code",
+				Run:   func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().Var(Enum("json", "json", "yaml", "text"), "format", "output format")
+			cmd.Flags().Var(Enum("go", "go", "rust", "python", "java", "kotlin", "swift", "ruby", "nix"), "lang", "language to format")
+			cmd.SetArgs([]string{"--help"})
+
+			err := Execute(cmd, append([]Option{WithStdout(&buf)}, tt.opts...)...)
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
 func TestHelpWithFlagGroups(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -184,6 +868,137 @@ func TestHelpWithFlagGroups(t *testing.T) {
 	golden.Assert(t, buf.String(), "help_with_flag_groups.golden")
 }
 
+func TestHelpWithFlagRequires(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use:   "drift",
+		Short: "Detect drift between environments",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+
+	cmd.Flags().BoolP("check", "c", false, "check for drift")
+	cmd.Flags().StringP("format", "f", "", "the format of the drift report")
+	cmd.Flags().StringP("output", "o", "", "write the drift report to a file")
+	cmd.Flags().BoolP("workspace", "w", false, "check all modules within the workspace")
+
+	MarkFlagRequires(cmd.Flags().Lookup("workspace"), "check")
+	MarkFlagRequires(cmd.Flags().Lookup("format"), "output", "check")
+
+	cmd.SetArgs([]string{"--help"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_flag_requires.golden")
+}
+
+func TestHelpWithAliases(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{name: "Parent", args: []string{"--help"}, golden: "help_with_aliases.golden"},
+		{name: "Command", args: []string{"next", "--help"}, golden: "help_with_aliases_command.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			next := newNextCmd()
+			next.Aliases = []string{"n", "nx"}
+			root.AddCommand(next, newTagCmd())
+			root.SetArgs(tt.args)
+
+			err := Execute(root, WithStdout(&buf))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithDeprecatedFlag(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newTagCmd()
+	cmd.Flags().Bool("sign", false, "sign the tag with GPG")
+	// Set directly, as MarkDeprecated also hides the flag from help
+	cmd.Flags().Lookup("sign").Deprecated = "use --gpg-key instead"
+	cmd.SetArgs([]string{"--help"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_deprecated_flag.golden")
+}
+
+func TestHelpWithDeprecatedCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		golden string
+	}{
+		{name: "Parent", args: []string{"--help"}, golden: "help_with_deprecated_command.golden"},
+		{name: "Command", args: []string{"tag", "--help"}, golden: "help_with_deprecated_command_detail.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			tag := newTagCmd()
+			tag.Deprecated = "tagging is now performed by the release command"
+			root.AddCommand(newNextCmd(), tag)
+			root.SetArgs(tt.args)
+
+			err := Execute(root, WithStdout(&buf))
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestHelpWithShowHidden(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []Option
+		golden string
+	}{
+		{name: "Default", golden: "help_without_hidden.golden"},
+		{name: "ShowHidden", opts: []Option{WithShowHidden()}, golden: "help_with_hidden.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			root := newRootCmd()
+			root.PersistentFlags().Bool("trace", false, "trace internal execution")
+			require.NoError(t, root.PersistentFlags().MarkHidden("trace"))
+
+			debug := &cobra.Command{
+				Use:    "debug",
+				Short:  "Dump internal state for troubleshooting",
+				Hidden: true,
+				Run:    func(_ *cobra.Command, _ []string) {},
+			}
+			root.AddCommand(newNextCmd(), debug)
+			root.SetArgs([]string{"--help"})
+
+			err := Execute(root, append([]Option{WithStdout(&buf), WithoutManpage()}, tt.opts...)...)
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
 func TestHelpWithEnum(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -225,6 +1040,77 @@ func TestHelpWithEnum(t *testing.T) {
 	golden.Assert(t, buf.String(), "help_with_enum.golden")
 }
 
+func TestHelpWithEnumDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use:   "gpg-import",
+		Short: "Import your GPG private key into the local keyring",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+
+	trust := Enum(trustUnknown, trustUnknown, trustNever, trustMarginal)
+	cmd.Flags().VarP(trust, "trust-level", "t", "a level of trust to associate with the GPG private key")
+	cmd.SetArgs([]string{"--help"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_enum_default.golden")
+}
+
+// externalEnum implements EnumHelper without naming its default value.
+type externalEnum struct{ value string }
+
+func (e *externalEnum) String() string            { return e.value }
+func (e *externalEnum) Set(s string) error        { e.value = s; return nil }
+func (e *externalEnum) Type() string              { return "json|yaml" }
+func (e *externalEnum) HasHelp() bool             { return false }
+func (e *externalEnum) HelpEntries() []EnumOption { return nil }
+func (e *externalEnum) BaseType() string          { return "string" }
+
+func TestFlagDefaultEnumWithoutDefaultName(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.Var(&externalEnum{value: "json"}, "format", "output format")
+
+	defValue, valueType, hasDefault := flagDefault(flags.Lookup("format"))
+	assert.Equal(t, "json", defValue)
+	assert.Equal(t, "string", valueType)
+	assert.True(t, hasDefault)
+}
+
+func TestHelpWithEnumHelpEntries(t *testing.T) {
+	var buf bytes.Buffer
+
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+		FormatTOML Format = "toml"
+	)
+
+	format := Enum(FormatJSON, FormatJSON, FormatYAML, FormatTOML).
+		WithHelp(
+			"JavaScript Object Notation",
+			"",
+			"Tom's Obvious Minimal Language, a config file format that is easy to read due to its obvious semantics",
+		)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the current configuration",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+
+	cmd.Flags().VarP(format, "format", "f", "the format to export the configuration in")
+	cmd.SetArgs([]string{"--help"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_with_enum_help_entries.golden")
+}
+
 func TestHelpWithEnvVars(t *testing.T) {
 	var buf bytes.Buffer
 
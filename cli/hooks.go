@@ -0,0 +1,43 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// wrapPersistentPreRun runs hook before the persistent pre-run of whichever
+// command is executed. Cobra only calls the nearest persistent pre-run to the
+// executed command, so hook is installed on the root and any descendant that
+// declares its own, leaving every other command to inherit it. This ensures
+// hook runs exactly once and no existing pre-run is shadowed.
+func wrapPersistentPreRun(root *cobra.Command, hook func(*cobra.Command, []string) error) {
+	wrapPreRun(root, root, hook)
+}
+
+func wrapPreRun(cmd, root *cobra.Command, hook func(*cobra.Command, []string) error) {
+	if cmd == root || cmd.PersistentPreRunE != nil || cmd.PersistentPreRun != nil {
+		existingPreRunE := cmd.PersistentPreRunE
+		existingPreRun := cmd.PersistentPreRun
+		isRoot := cmd == root
+
+		cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+			// Cobra can be configured to run the pre-run of every ancestor,
+			// in which case only the root runs the hook
+			if isRoot || !cobra.EnableTraverseRunHooks {
+				if err := hook(c, args); err != nil {
+					return err
+				}
+			}
+
+			if existingPreRunE != nil {
+				return existingPreRunE(c, args)
+			}
+			if existingPreRun != nil {
+				existingPreRun(c, args)
+			}
+			return nil
+		}
+		cmd.PersistentPreRun = nil
+	}
+
+	for _, sub := range cmd.Commands() {
+		wrapPreRun(sub, root, hook)
+	}
+}
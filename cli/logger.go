@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	logLevelFlag = "log-level"
+	noColorFlag  = "no-color"
+	noLogFlag    = "no-log"
+)
+
+// Styles of the level label written by the logger, when color is enabled.
+var logLevelStyles = map[slog.Level]lipgloss.Style{
+	slog.LevelDebug: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("8")),
+	slog.LevelInfo:  lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12")),
+	slog.LevelWarn:  lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11")),
+	slog.LevelError: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9")),
+}
+
+type loggerKey struct{}
+
+// WithLogger configures a [log/slog] logger for the executed command, writing
+// to stderr, which is retrieved with [Logger]. It is driven by three
+// persistent flags on the root command, which are added if not already
+// defined:
+//
+//   - --log-level sets the minimum level logged (debug, info, warn or error)
+//   - --no-color disables colored output
+//   - --no-log discards all log output
+//
+// An existing --log-level flag, such as an [Enum], can use any value accepted
+// by [slog.Level.UnmarshalText].
+//
+// When combined with [WithVerbosity] and --log-level is not set, --quiet only
+// logs errors and every -v logs one level below info, so -v logs debug.
+//
+//	cli.Execute(root, cli.WithLogger())
+func WithLogger() Option {
+	return func(o *options) {
+		o.logger = true
+	}
+}
+
+// Logger returns the logger configured by [WithLogger] for the executed
+// command, or [slog.Default] if there is none.
+//
+//	RunE: func(cmd *cobra.Command, args []string) error {
+//	    cli.Logger(cmd).Info("tagging repository", "version", version)
+//	    ...
+//	}
+func Logger(cmd *cobra.Command) *slog.Logger {
+	if ctx := cmd.Context(); ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+func addLogFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	defined := func(name string) bool {
+		return flags.Lookup(name) != nil || cmd.Flags().Lookup(name) != nil
+	}
+
+	if !defined(logLevelFlag) {
+		flags.Var(Enum("info", "debug", "info", "warn", "error"), logLevelFlag, "set the logging verbosity")
+	}
+	if !defined(noColorFlag) {
+		flags.Bool(noColorFlag, false, "disable colored output")
+	}
+	if !defined(noLogFlag) {
+		flags.Bool(noLogFlag, false, "disable all log output")
+	}
+}
+
+// addLogger configures the logger once every flag of the executed command
+// has been resolved, including from the environment and any config file.
+func addLogger(cmd *cobra.Command, w io.Writer) {
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		logger, err := newLogger(c.Flags(), w)
+		if err != nil {
+			return err
+		}
+		c.SetContext(context.WithValue(c.Context(), loggerKey{}, logger))
+		return nil
+	})
+}
+
+func newLogger(flags *pflag.FlagSet, w io.Writer) (*slog.Logger, error) {
+	if noLog, err := flags.GetBool(noLogFlag); err == nil && noLog {
+		return slog.New(slog.DiscardHandler), nil
+	}
+
+	var level slog.Level
+	if f := flags.Lookup(logLevelFlag); f != nil {
+		if err := level.UnmarshalText([]byte(f.Value.String())); err != nil {
+			return nil, fmt.Errorf("invalid value for --%s: %w", logLevelFlag, err)
+		}
+	}
+
+	if !flags.Changed(logLevelFlag) {
+		if quiet, err := flags.GetBool(quietFlag); err == nil && quiet {
+			level = slog.LevelError
+		} else if verbose, err := flags.GetCount(verboseFlag); err == nil && verbose > 0 {
+			level = slog.LevelInfo - slog.Level(4*verbose)
+		}
+	}
+
+	color, _ := colorMode(w)
+	if noColor, err := flags.GetBool(noColorFlag); err == nil && noColor {
+		color = false
+	}
+
+	return slog.New(&logHandler{mu: &sync.Mutex{}, w: w, level: level, color: color}), nil
+}
+
+// logHandler is a [slog.Handler] that writes human-readable records, such as
+// INFO tagging repository version=v1.2.0, coloring the level if enabled.
+type logHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  slog.Level
+	color  bool
+	attrs  string
+	prefix string
+}
+
+func (h *logHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *logHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(h.levelLabel(r.Level))
+	b.WriteString(" ")
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		appendLogAttr(&b, h.prefix, a)
+		return true
+	})
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	for _, a := range attrs {
+		appendLogAttr(&b, h.prefix, a)
+	}
+
+	clone := *h
+	clone.attrs += b.String()
+	return &clone
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.prefix += name + "."
+	return &clone
+}
+
+func (h *logHandler) levelLabel(level slog.Level) string {
+	label := fmt.Sprintf("%-5s", level.String())
+	if !h.color {
+		return label
+	}
+
+	style := logLevelStyles[slog.LevelError]
+	switch {
+	case level < slog.LevelInfo:
+		style = logLevelStyles[slog.LevelDebug]
+	case level < slog.LevelWarn:
+		style = logLevelStyles[slog.LevelInfo]
+	case level < slog.LevelError:
+		style = logLevelStyles[slog.LevelWarn]
+	}
+	return style.Render(label)
+}
+
+func appendLogAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			appendLogAttr(b, prefix, ga)
+		}
+		return
+	}
+
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	fmt.Fprintf(b, " %s%s=%s", prefix, a.Key, value)
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeWithLogger(t *testing.T, root *cobra.Command, args ...string) (*slog.Logger, string) {
+	t.Helper()
+
+	var logger *slog.Logger
+	root.Run = func(cmd *cobra.Command, _ []string) {
+		logger = Logger(cmd)
+		logger.Debug("resolving version", "path", ".")
+		logger.Info("tagged repository", "version", "v1.2.0")
+		logger.Warn("tag not pushed")
+		logger.Error("release failed", "reason", "no permission")
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := Execute(root, WithStdout(&stdout), WithStderr(&stderr), WithLogger(), WithArgs(args...))
+	require.NoError(t, err)
+	require.NotNil(t, logger)
+
+	return logger, stderr.String()
+}
+
+func TestWithLoggerLevel(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+
+	tests := []struct {
+		name     string
+		args     []string
+		level    slog.Level
+		expected string
+	}{
+		{
+			name:  "Default",
+			level: slog.LevelInfo,
+			expected: `INFO  tagged repository version=v1.2.0
+WARN  tag not pushed
+ERROR release failed reason="no permission"
+`,
+		},
+		{
+			name:  "Debug",
+			args:  []string{"--log-level", "debug"},
+			level: slog.LevelDebug,
+			expected: `DEBUG resolving version path=.
+INFO  tagged repository version=v1.2.0
+WARN  tag not pushed
+ERROR release failed reason="no permission"
+`,
+		},
+		{
+			name:     "Error",
+			args:     []string{"--log-level", "error"},
+			level:    slog.LevelError,
+			expected: "ERROR release failed reason=\"no permission\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, stderr := executeWithLogger(t, &cobra.Command{Use: "nsv"}, tt.args...)
+
+			ctx := context.Background()
+			assert.True(t, logger.Enabled(ctx, tt.level))
+			assert.False(t, logger.Enabled(ctx, tt.level-1))
+			assert.Equal(t, tt.expected, stderr)
+		})
+	}
+}
+
+func TestWithLoggerExistingFlags(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+
+	logger, stderr := executeWithLogger(t, newRootCmd(), "-l", "warn")
+
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelInfo))
+	assert.Equal(t, "WARN  tag not pushed\nERROR release failed reason=\"no permission\"\n", stderr)
+}
+
+func TestWithLoggerNoLog(t *testing.T) {
+	logger, stderr := executeWithLogger(t, &cobra.Command{Use: "nsv"}, "--no-log", "--log-level", "debug")
+
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelError))
+	assert.Empty(t, stderr)
+}
+
+func TestWithLoggerVerbosity(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		level slog.Level
+	}{
+		{name: "Default", level: slog.LevelInfo},
+		{name: "Verbose", args: []string{"-v"}, level: slog.LevelDebug},
+		{name: "VeryVerbose", args: []string{"-vv"}, level: slog.LevelDebug - 4},
+		{name: "Quiet", args: []string{"--quiet"}, level: slog.LevelError},
+		{name: "LogLevelTakesPrecedence", args: []string{"-v", "--log-level", "warn"}, level: slog.LevelWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logger *slog.Logger
+			root := &cobra.Command{
+				Use: "nsv",
+				Run: func(cmd *cobra.Command, _ []string) {
+					logger = Logger(cmd)
+				},
+			}
+
+			err := Execute(root, WithStderr(&bytes.Buffer{}), WithLogger(), WithVerbosity(), WithArgs(tt.args...))
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			assert.True(t, logger.Enabled(ctx, tt.level))
+			assert.False(t, logger.Enabled(ctx, tt.level-1))
+		})
+	}
+}
+
+func TestWithLoggerColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+
+	_, stderr := executeWithLogger(t, &cobra.Command{Use: "nsv"})
+	assert.Contains(t, stderr, "\x1b[")
+
+	_, stderr = executeWithLogger(t, &cobra.Command{Use: "nsv"}, "--no-color")
+	assert.NotContains(t, stderr, "\x1b[")
+}
+
+func TestLogHandlerAttrsAndGroups(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(&logHandler{mu: &sync.Mutex{}, w: &buf, level: slog.LevelInfo})
+	logger.With("repo", "nsv").WithGroup("git").Info("pushed", "tag", "v1.2.0", slog.Group("remote", "name", "origin"))
+	assert.Equal(t, "INFO  pushed repo=nsv git.tag=v1.2.0 git.remote.name=origin\n", buf.String())
+}
+
+func TestLoggerDefault(t *testing.T) {
+	cmd := &cobra.Command{Use: "nsv"}
+	assert.Equal(t, slog.Default(), Logger(cmd))
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func newManCommand(enumLimit int) *cobra.Command {
+	var outputDir string
+
+	cmd := &cobra.Command{
+		Use:                   "man",
+		Short:                 "Generate manpages for the CLI",
+		SilenceUsage:          true,
+		DisableFlagsInUseLine: true,
+		Hidden:                true,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			root := cmd.Root()
+			if outputDir == "" {
+				_, err := fmt.Fprint(cmd.OutOrStdout(), renderManPage(root, enumLimit))
+				return err
+			}
+
+			if err := os.MkdirAll(outputDir, 0o755); err != nil {
+				return fmt.Errorf("creating manpage directory: %w", err)
+			}
+			return writeManPages(outputDir, root, enumLimit)
+		},
+	}
+
+	cmd.Flags().StringVar(&outputDir, "output-dir", "", "write a manpage for every command to this directory")
+
+	return cmd
+}
+
+func writeManPages(dir string, cmd *cobra.Command, enumLimit int) error {
+	path := filepath.Join(dir, commandPageName(cmd)+".1")
+	if err := os.WriteFile(path, []byte(renderManPage(cmd, enumLimit)), 0o644); err != nil {
+		return fmt.Errorf("writing manpage: %w", err)
+	}
+
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		if err := writeManPages(dir, sub, enumLimit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func commandPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// renderManPage renders a roff formatted manpage for a command, sourcing its
+// content from the same metadata as the help output.
+func renderManPage(cmd *cobra.Command, enumLimit int) string {
+	var b strings.Builder
+
+	name := commandPageName(cmd)
+	fmt.Fprintf(&b, ".TH %q \"1\" \"\" %q \"User Commands\"\n", strings.ToUpper(name), cmd.Root().Name())
+
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	usage := strings.TrimPrefix(formatUsage(cmd, DefaultTheme()), cmd.CommandPath())
+	fmt.Fprintf(&b, "\\fB%s\\fR%s\n", roffEscape(cmd.CommandPath()), roffEscape(usage))
+
+	desc := cmd.Long
+	if desc == "" {
+		desc = cmd.Short
+	}
+	if desc != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		writeRoffParagraphs(&b, dedent(desc))
+	}
+
+	if hasSubCommands(cmd) {
+		b.WriteString(".SH COMMANDS\n")
+		for _, sub := range cmd.Commands() {
+			if sub.Hidden {
+				continue
+			}
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(sub.Name()), roffEscape(sub.Short))
+		}
+	}
+
+	if cmd.Example != "" {
+		b.WriteString(".SH EXAMPLES\n")
+		b.WriteString(".PP\n.RS 4\n.nf\n")
+		for line := range strings.SplitSeq(dedent(cmd.Example), "\n") {
+			b.WriteString(roffEscapeLine(line) + "\n")
+		}
+		b.WriteString(".fi\n.RE\n")
+	}
+
+	sections := pageFlagSections(cmd)
+	for _, section := range sections {
+		fmt.Fprintf(&b, ".SH %s\n", roffEscape(section.name))
+		writeRoffFlags(&b, section.flags, enumLimit)
+	}
+
+	if bindings := pageEnvBindings(sections); len(bindings) > 0 {
+		b.WriteString(".SH ENVIRONMENT\n")
+		for _, f := range bindings {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\nSets the value of \\fB\\-\\-%s\\fR\n", roffEscape(GetEnvVar(f)), roffEscape(f.Name))
+		}
+	}
+
+	// Manpages also refer to every subcommand, as is conventional
+	seeAlso := pageSeeAlso(cmd)
+	for _, sub := range cmd.Commands() {
+		if !sub.Hidden && !slices.Contains(seeAlso, sub) {
+			seeAlso = append(seeAlso, sub)
+		}
+	}
+	if len(seeAlso) > 0 {
+		refs := make([]string, len(seeAlso))
+		for i, c := range seeAlso {
+			refs[i] = fmt.Sprintf("\\fB%s\\fR(1)", roffEscape(commandPageName(c)))
+		}
+		b.WriteString(".SH SEE ALSO\n")
+		b.WriteString(strings.Join(refs, ", ") + "\n")
+	}
+
+	return b.String()
+}
+
+func writeRoffFlags(b *strings.Builder, flags []*pflag.Flag, enumLimit int) {
+	for _, f := range flags {
+		pf := newPageFlag(f, enumLimit)
+
+		b.WriteString(".TP\n")
+		if pf.shorthand != "" {
+			fmt.Fprintf(b, "\\fB\\-%s\\fR, ", roffEscape(pf.shorthand))
+		}
+		fmt.Fprintf(b, "\\fB\\-\\-%s\\fR", roffEscape(pf.name))
+		if pf.placeholder != "" {
+			fmt.Fprintf(b, " \\fI<%s>\\fR", roffEscape(pf.placeholder))
+		}
+		b.WriteString("\n")
+
+		desc := pf.usage
+		if pf.defValue != "" {
+			desc += " (default: " + pf.defValue + ")"
+		}
+		b.WriteString(roffEscapeLine(desc) + "\n")
+
+		if len(pf.values) > 0 {
+			b.WriteString(".RS\n.PP\nPossible values:\n")
+			for _, entry := range pf.values {
+				fmt.Fprintf(b, ".TP\n\\fB%s\\fR\n", roffEscape(entry.Name))
+				if entry.Help != "" {
+					b.WriteString(roffEscapeLine(entry.Help) + "\n")
+				}
+			}
+			b.WriteString(".RE\n")
+		}
+	}
+}
+
+func writeRoffParagraphs(b *strings.Builder, text string) {
+	for i, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if i > 0 {
+			b.WriteString(".PP\n")
+		}
+		for line := range strings.SplitSeq(para, "\n") {
+			b.WriteString(roffEscapeLine(strings.TrimSpace(line)) + "\n")
+		}
+	}
+}
+
+// roffEscape escapes characters with a special meaning within roff text.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	return strings.ReplaceAll(s, "-", `\-`)
+}
+
+// roffEscapeLine escapes a complete line of text, guarding against it being
+// interpreted as a roff request.
+func roffEscapeLine(s string) string {
+	s = roffEscape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/golden"
+)
+
+func TestManPage(t *testing.T) {
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd(), newTagCmd())
+	root.SetArgs([]string{"man"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "man.golden")
+}
+
+func TestManPageOutputDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "man1")
+
+	root := newRootCmd()
+	next := newNextCmd()
+	BindEnv(next.Flags().Lookup("format"), "NSV_FORMAT")
+	MarkRelated(next, "tag")
+	root.AddCommand(next, newTagCmd())
+	root.SetArgs([]string{"man", "--output-dir", dir})
+
+	err := Execute(root, WithStdout(&bytes.Buffer{}))
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"nsv.1", "nsv-next.1", "nsv-tag.1"}, names)
+
+	page, err := os.ReadFile(filepath.Join(dir, "nsv-next.1"))
+	require.NoError(t, err)
+	golden.Assert(t, string(page), "man_subcommand.golden")
+}
+
+func TestManPageEnumValues(t *testing.T) {
+	type Format string
+	const (
+		FormatJSON Format = "json"
+		FormatYAML Format = "yaml"
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the current configuration",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	format := Enum(FormatJSON, FormatJSON, FormatYAML).WithHelp("JavaScript Object Notation", "")
+	cmd.Flags().Var(format, "format", "the export format")
+
+	page := renderManPage(cmd, DefaultEnumLimit)
+	assert.Contains(t, page, ".SH FLAGS\n")
+	assert.Contains(t, page, "Possible values:\n.TP\n\\fBjson\\fR\nJavaScript Object Notation\n.TP\n\\fByaml\\fR\n")
+}
+
+func TestManPageEnumPlaceholder(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use:   "fmt",
+		Short: "Format the source code",
+		Run:   func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(Enum("go", "go", "rust", "python", "java"), "lang", "language to format")
+	cmd.Flags().Var(Enum("json", "json", "yaml").WithHelp("JavaScript Object Notation"), "format", "output format")
+	cmd.SetArgs([]string{"man"})
+
+	err := Execute(cmd, WithStdout(&buf), WithEnumLimit(2))
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), `\fB\-\-lang\fR \fI<go|rust|...>\fR`)
+	assert.Contains(t, buf.String(), `\fB\-\-format\fR \fI<string>\fR`)
+}
+
+func TestRoffEscapeLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{name: "Dash", line: "use --verbose", expected: `use \-\-verbose`},
+		{name: "Backslash", line: `a\b`, expected: `a\eb`},
+		{name: "LeadingPeriod", line: ".env file", expected: `\&.env file`},
+		{name: "LeadingQuote", line: "'quoted'", expected: `\&'quoted'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, roffEscapeLine(tt.line))
+		})
+	}
+}
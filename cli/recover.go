@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// PanicError is returned from [Execute] in place of a panic raised while
+// running a command, when enabled through [WithRecover].
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the goroutine that panicked. It is only
+	// captured when verbose output is requested through [WithVerbosity].
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	if len(e.Stack) == 0 {
+		return fmt.Sprintf("panic: %v", e.Value)
+	}
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the value passed to panic if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// WithRecover recovers from a panic raised while running a command, returning
+// it from [Execute] as a [PanicError] rather than crashing the process. The
+// error is reported like any other, including through [WithErrorFormat]. If
+// [WithVerbosity] is enabled and -v is provided, the error includes the stack
+// trace of the panic.
+//
+//	cli.Execute(root, cli.WithRecover())
+func WithRecover() Option {
+	return func(o *options) {
+		o.recover = true
+	}
+}
+
+// addRecover wraps the run function of every command in the tree so that a
+// panic is returned as an error, allowing cobra to report it.
+func addRecover(cmd *cobra.Command) {
+	if cmd.RunE != nil || cmd.Run != nil {
+		runE, run := cmd.RunE, cmd.Run
+
+		cmd.RunE = func(c *cobra.Command, args []string) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					perr := &PanicError{Value: r}
+					if Verbosity(c) > 0 {
+						perr.Stack = debug.Stack()
+					}
+					err = perr
+				}
+			}()
+
+			if runE != nil {
+				return runE(c, args)
+			}
+			run(c, args)
+			return nil
+		}
+		cmd.Run = nil
+	}
+
+	for _, sub := range cmd.Commands() {
+		addRecover(sub)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRecover(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name  string
+		cmd   *cobra.Command
+		value any
+	}{
+		{
+			name: "RunE",
+			cmd: &cobra.Command{
+				Use:  "test",
+				RunE: func(_ *cobra.Command, _ []string) error { panic("boom") },
+			},
+			value: "boom",
+		},
+		{
+			name: "Run",
+			cmd: &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) { panic(errBoom) },
+			},
+			value: errBoom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			err := Execute(tt.cmd, WithStdout(&stdout), WithStderr(&stderr), WithRecover(), WithArgs())
+			require.Error(t, err)
+
+			var perr *PanicError
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.value, perr.Value)
+			assert.Empty(t, perr.Stack)
+			assert.Equal(t, "Error: panic: boom\n", stderr.String())
+		})
+	}
+}
+
+func TestWithRecoverUnwrapsError(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cmd := &cobra.Command{
+		Use:  "test",
+		RunE: func(_ *cobra.Command, _ []string) error { panic(errBoom) },
+	}
+
+	err := Execute(cmd, WithStderr(&bytes.Buffer{}), WithRecover(), WithArgs())
+	require.ErrorIs(t, err, errBoom)
+}
+
+func TestWithRecoverVerboseStack(t *testing.T) {
+	cmd := &cobra.Command{
+		Use:  "test",
+		RunE: func(_ *cobra.Command, _ []string) error { panic("boom") },
+	}
+
+	err := Execute(cmd, WithStderr(&bytes.Buffer{}), WithRecover(), WithVerbosity(), WithArgs("-v"))
+
+	var perr *PanicError
+	require.ErrorAs(t, err, &perr)
+	assert.NotEmpty(t, perr.Stack)
+	assert.Contains(t, err.Error(), "runtime/debug.Stack")
+}
+
+func TestWithRecoverErrorFormatJSON(t *testing.T) {
+	var stderr bytes.Buffer
+
+	root := &cobra.Command{Use: "app"}
+	root.AddCommand(&cobra.Command{
+		Use: "next",
+		Run: func(_ *cobra.Command, _ []string) { panic("boom") },
+	})
+
+	err := Execute(root, WithStderr(&stderr), WithRecover(), WithErrorFormat(ErrorFormatJSON), WithArgs("next"))
+	require.Error(t, err)
+	assert.Equal(t, `{"error":"panic: boom","command":"app next"}`+"\n", stderr.String())
+}
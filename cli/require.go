@@ -3,12 +3,18 @@ package cli
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
-const flagRequiresAnnotation = "purpleclay_cli_requires"
+const (
+	flagRequiresAnnotation   = "purpleclay_cli_requires"
+	flagConflictsAnnotation  = "purpleclay_cli_conflicts"
+	flagTogetherAnnotation   = "purpleclay_cli_required_together"
+	flagRequiredIfAnnotation = "purpleclay_cli_required_if"
+)
 
 // MarkFlagRequires specifies that if flag is set, the named required flags
 // must also be set. This is a one-way dependency - the required flags can
@@ -51,28 +57,154 @@ func GetFlagRequires(flag *pflag.Flag) []string {
 	return nil
 }
 
-func addFlagRequirementsValidation(cmd *cobra.Command) {
-	existingPreRunE := cmd.PersistentPreRunE
-	existingPreRun := cmd.PersistentPreRun
+// MarkFlagConflicts specifies that if flag is set, none of the named
+// conflicting flags may also be set. This is a one-way restriction - the
+// conflicting flags can be used independently.
+//
+// If flag is nil, MarkFlagConflicts silently returns without effect (no-op).
+//
+//	cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+//	cmd.Flags().StringVar(&tmpl, "template", "", "output using a go template")
+//
+//	cli.MarkFlagConflicts(cmd.Flags().Lookup("json"), "template")
+//
+// During command execution, if --json is provided with --template,
+// an error is returned: "flag --json conflicts with --template"
+func MarkFlagConflicts(flag *pflag.Flag, flagNames ...string) {
+	if flag == nil {
+		return
+	}
 
-	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
-		if err := validateFlagRequirements(c); err != nil {
-			return err
-		}
+	if flag.Annotations == nil {
+		flag.Annotations = make(map[string][]string)
+	}
+	flag.Annotations[flagConflictsAnnotation] = append(
+		flag.Annotations[flagConflictsAnnotation], flagNames...)
+}
+
+// GetFlagConflicts returns the flags that conflict with the given flag,
+// or nil if no conflicts exist.
+func GetFlagConflicts(flag *pflag.Flag) []string {
+	if flag == nil || flag.Annotations == nil {
+		return nil
+	}
+	if conflicts, ok := flag.Annotations[flagConflictsAnnotation]; ok {
+		return conflicts
+	}
+	return nil
+}
+
+// MarkFlagRequiredIf specifies that flag must be set whenever the named other
+// flag has the given value, including when that value is its default. Multiple
+// conditions can be added by calling MarkFlagRequiredIf again.
+//
+// If flag is nil, MarkFlagRequiredIf silently returns without effect (no-op).
+//
+//	cmd.Flags().StringVar(&backend, "backend", "local", "where to store state")
+//	cmd.Flags().StringVar(&bucket, "bucket", "", "name of the S3 bucket")
+//
+//	cli.MarkFlagRequiredIf(cmd.Flags().Lookup("bucket"), "backend", "s3")
+//
+// During command execution, if --backend s3 is provided without --bucket,
+// an error is returned: "flag --bucket is required when --backend is s3"
+func MarkFlagRequiredIf(flag *pflag.Flag, other, equals string) {
+	if flag == nil {
+		return
+	}
+
+	if flag.Annotations == nil {
+		flag.Annotations = make(map[string][]string)
+	}
+	flag.Annotations[flagRequiredIfAnnotation] = append(
+		flag.Annotations[flagRequiredIfAnnotation], other+"="+equals)
+}
 
-		if existingPreRunE != nil {
-			return existingPreRunE(c, args)
+// MarkFlagsRequiredTogether specifies that the named flags must be used
+// together. If any flag within the group is set, they all must be set.
+//
+// MarkFlagsRequiredTogether panics if a named flag does not exist on cmd, as
+// this indicates a programming error.
+//
+//	cmd.Flags().StringVar(&user, "user", "", "username for the registry")
+//	cmd.Flags().StringVar(&password, "password", "", "password for the registry")
+//
+//	cli.MarkFlagsRequiredTogether(cmd, "user", "password")
+//
+// During command execution, if --user is provided without --password, an
+// error is returned: "flags --user and --password must be used together"
+func MarkFlagsRequiredTogether(cmd *cobra.Command, flagNames ...string) {
+	group := strings.Join(flagNames, " ")
+	for _, name := range flagNames {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			panic(fmt.Sprintf("cli: flag %q does not exist", name))
 		}
-		if existingPreRun != nil {
-			existingPreRun(c, args)
+
+		if flag.Annotations == nil {
+			flag.Annotations = make(map[string][]string)
 		}
-		return nil
+		flag.Annotations[flagTogetherAnnotation] = append(
+			flag.Annotations[flagTogetherAnnotation], group)
 	}
-	cmd.PersistentPreRun = nil
+}
 
-	for _, sub := range cmd.Commands() {
-		addFlagRequirementsValidation(sub)
+// flagValidators holds the validators registered with MarkFlagValidate. They
+// are keyed by flag, as annotations can only store strings.
+var flagValidators = struct {
+	sync.Mutex
+	fns map[*pflag.Flag][]func(string) error
+}{fns: make(map[*pflag.Flag][]func(string) error)}
+
+// MarkFlagValidate registers a validator that is run against the value of
+// flag whenever it is set. Validators run alongside any requirements and
+// conflicts, before the command is executed. Multiple validators can be
+// registered for the same flag and are run in order.
+//
+// If flag is nil, MarkFlagValidate silently returns without effect (no-op).
+//
+//	cmd.Flags().IntVar(&port, "port", 8080, "port to listen on")
+//
+//	cli.MarkFlagValidate(cmd.Flags().Lookup("port"), func(v string) error {
+//	    if p, err := strconv.Atoi(v); err != nil || p < 1 || p > 65535 {
+//	        return errors.New("must be between 1 and 65535")
+//	    }
+//	    return nil
+//	})
+//
+// During command execution, if --port 0 is provided, an error is returned:
+// "invalid value for --port: must be between 1 and 65535"
+func MarkFlagValidate(flag *pflag.Flag, fn func(string) error) {
+	if flag == nil || fn == nil {
+		return
 	}
+
+	flagValidators.Lock()
+	defer flagValidators.Unlock()
+	flagValidators.fns[flag] = append(flagValidators.fns[flag], fn)
+}
+
+// addFlagRequirementsValidation validates flag requirements of the executed
+// command before any existing persistent pre-run hook. If silenceUsage is set,
+// usage is silenced once all flag validation has passed, so errors raised while
+// running the command are not mistaken for misuse.
+func addFlagRequirementsValidation(cmd *cobra.Command, silenceUsage bool) {
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		if err := validateFlagRequirements(c); err != nil {
+			return err
+		}
+
+		if silenceUsage {
+			// Cobra only validates these after the pre-run hooks
+			if err := c.ValidateRequiredFlags(); err != nil {
+				return err
+			}
+			if err := c.ValidateFlagGroups(); err != nil {
+				return err
+			}
+			c.SilenceUsage = true
+		}
+		return nil
+	})
 }
 
 func validateFlagRequirements(cmd *cobra.Command) error {
@@ -84,6 +216,22 @@ func validateFlagRequirements(cmd *cobra.Command) error {
 		}
 		if err := validateFlagRequires(cmd.Flags(), f); err != nil {
 			validateErr = err
+			return
+		}
+		if err := validateFlagConflicts(cmd.Flags(), f); err != nil {
+			validateErr = err
+			return
+		}
+		if err := validateFlagRequiredIf(cmd.Flags(), f); err != nil {
+			validateErr = err
+			return
+		}
+		if err := validateFlagsTogether(cmd.Flags(), f); err != nil {
+			validateErr = err
+			return
+		}
+		if err := validateFlagValue(f); err != nil {
+			validateErr = err
 		}
 	})
 
@@ -110,3 +258,84 @@ func validateFlagRequires(flags *pflag.FlagSet, flag *pflag.Flag) error {
 
 	return nil
 }
+
+func validateFlagConflicts(flags *pflag.FlagSet, flag *pflag.Flag) error {
+	conflicts := GetFlagConflicts(flag)
+	if len(conflicts) == 0 || !flag.Changed {
+		return nil
+	}
+
+	var present []string
+	for _, name := range conflicts {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			present = append(present, "--"+name)
+		}
+	}
+
+	if len(present) > 0 {
+		return fmt.Errorf("flag --%s conflicts with %s", flag.Name, strings.Join(present, ", "))
+	}
+
+	return nil
+}
+
+func validateFlagRequiredIf(flags *pflag.FlagSet, flag *pflag.Flag) error {
+	if flag.Changed {
+		return nil
+	}
+
+	for _, cond := range flag.Annotations[flagRequiredIfAnnotation] {
+		name, value, _ := strings.Cut(cond, "=")
+		if other := flags.Lookup(name); other != nil && other.Value.String() == value {
+			return fmt.Errorf("flag --%s is required when --%s is %s", flag.Name, name, value)
+		}
+	}
+
+	return nil
+}
+
+func validateFlagsTogether(flags *pflag.FlagSet, flag *pflag.Flag) error {
+	if !flag.Changed {
+		return nil
+	}
+
+	for _, group := range flag.Annotations[flagTogetherAnnotation] {
+		names := strings.Fields(group)
+
+		var missing bool
+		for _, name := range names {
+			if f := flags.Lookup(name); f == nil || !f.Changed {
+				missing = true
+			}
+		}
+
+		if missing {
+			flagNames := make([]string, len(names))
+			for i, name := range names {
+				flagNames[i] = "--" + name
+			}
+			last := len(flagNames) - 1
+			return fmt.Errorf("flags %s and %s must be used together",
+				strings.Join(flagNames[:last], ", "), flagNames[last])
+		}
+	}
+
+	return nil
+}
+
+func validateFlagValue(flag *pflag.Flag) error {
+	if !flag.Changed {
+		return nil
+	}
+
+	flagValidators.Lock()
+	fns := flagValidators.fns[flag]
+	flagValidators.Unlock()
+
+	for _, fn := range fns {
+		if err := fn(flag.Value.String()); err != nil {
+			return fmt.Errorf("invalid value for --%s: %w", flag.Name, err)
+		}
+	}
+	return nil
+}
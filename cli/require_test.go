@@ -2,6 +2,8 @@ package cli
 
 import (
 	"bytes"
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -135,6 +137,69 @@ func TestMarkFlagRequiresPreservesExistingPreRunE(t *testing.T) {
 	assert.True(t, preRunExecuted)
 }
 
+// newNestedPreRunCmd builds a three level command tree, where the middle
+// command declares a persistent pre-run that counts its invocations.
+func newNestedPreRunCmd(preRuns *int) *cobra.Command {
+	root := &cobra.Command{Use: "app"}
+	mid := &cobra.Command{
+		Use: "remote",
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			*preRuns++
+			return nil
+		},
+	}
+	leaf := &cobra.Command{
+		Use: "add",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	leaf.Flags().Bool("check", false, "check for drift")
+	leaf.Flags().Bool("workspace", false, "use workspace")
+	MarkFlagRequires(leaf.Flags().Lookup("workspace"), "check")
+
+	mid.AddCommand(leaf)
+	root.AddCommand(mid)
+	return root
+}
+
+func TestMarkFlagRequiresInheritedPreRunE(t *testing.T) {
+	tests := []struct {
+		name     string
+		traverse bool
+	}{
+		{name: "NearestHook", traverse: false},
+		{name: "TraverseHooks", traverse: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(traverse bool) { cobra.EnableTraverseRunHooks = traverse }(cobra.EnableTraverseRunHooks)
+			cobra.EnableTraverseRunHooks = tt.traverse
+
+			var buf bytes.Buffer
+			var preRuns int
+
+			root := newNestedPreRunCmd(&preRuns)
+			root.SetArgs([]string{"remote", "add", "--workspace", "--check"})
+
+			err := Execute(root, WithStdout(&buf))
+			require.NoError(t, err)
+			assert.Equal(t, 1, preRuns)
+		})
+	}
+}
+
+func TestMarkFlagRequiresInheritedPreRunEValidatesLeaf(t *testing.T) {
+	var buf bytes.Buffer
+	var preRuns int
+
+	root := newNestedPreRunCmd(&preRuns)
+	root.SetArgs([]string{"remote", "add", "--workspace"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.EqualError(t, err, "flag --workspace requires --check")
+	assert.Equal(t, 0, preRuns)
+}
+
 func TestMarkFlagRequiresPreservesExistingPreRun(t *testing.T) {
 	var buf bytes.Buffer
 	var check, workspace bool
@@ -157,3 +222,253 @@ func TestMarkFlagRequiresPreservesExistingPreRun(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, preRunExecuted)
 }
+
+func TestMarkFlagConflicts(t *testing.T) {
+	var buf bytes.Buffer
+	var jsonOut bool
+	var tmpl string
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+	cmd.Flags().StringVar(&tmpl, "template", "", "output using a go template")
+	MarkFlagConflicts(cmd.Flags().Lookup("json"), "template")
+
+	cmd.SetArgs([]string{"--json", "--template", "{{.Version}}"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "flag --json conflicts with --template")
+}
+
+func TestMarkFlagConflictsIndependentFlagsWork(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "WithFlag", args: []string{"--json"}},
+		{name: "WithConflictingFlag", args: []string{"--template", "{{.Version}}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var jsonOut bool
+			var tmpl string
+
+			cmd := &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+			cmd.Flags().StringVar(&tmpl, "template", "", "output using a go template")
+			MarkFlagConflicts(cmd.Flags().Lookup("json"), "template")
+
+			cmd.SetArgs(tt.args)
+
+			err := Execute(cmd, WithStdout(&buf))
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMarkFlagConflictsMultipleConflicts(t *testing.T) {
+	var buf bytes.Buffer
+	var jsonOut, yamlOut, textOut bool
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "output as JSON")
+	cmd.Flags().BoolVar(&yamlOut, "yaml", false, "output as YAML")
+	cmd.Flags().BoolVar(&textOut, "text", false, "output as text")
+	MarkFlagConflicts(cmd.Flags().Lookup("json"), "yaml", "text")
+
+	cmd.SetArgs([]string{"--json", "--yaml", "--text"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "flag --json conflicts with --yaml, --text")
+}
+
+func TestMarkFlagConflictsNilFlag(_ *testing.T) {
+	MarkFlagConflicts(nil, "template")
+}
+
+func newPortCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "serve",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Int("port", 8080, "port to listen on")
+	MarkFlagValidate(cmd.Flags().Lookup("port"), func(v string) error {
+		if p, err := strconv.Atoi(v); err != nil || p < 1 || p > 65535 {
+			return errors.New("must be between 1 and 65535")
+		}
+		return nil
+	})
+	return cmd
+}
+
+func TestMarkFlagValidate(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newPortCmd()
+	cmd.SetArgs([]string{"--port", "9090"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+}
+
+func TestMarkFlagValidateInvalidValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newPortCmd()
+	cmd.SetArgs([]string{"--port", "0"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.EqualError(t, err, "invalid value for --port: must be between 1 and 65535")
+}
+
+func TestMarkFlagValidateSkipsUnchangedFlag(t *testing.T) {
+	var buf bytes.Buffer
+	var called bool
+
+	cmd := &cobra.Command{
+		Use: "serve",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Int("port", 0, "port to listen on")
+	MarkFlagValidate(cmd.Flags().Lookup("port"), func(_ string) error {
+		called = true
+		return errors.New("must be between 1 and 65535")
+	})
+	cmd.SetArgs([]string{})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.False(t, called)
+}
+
+func TestMarkFlagValidateNilFlag(_ *testing.T) {
+	MarkFlagValidate(nil, func(_ string) error { return nil })
+}
+
+func TestMarkFlagsRequiredTogether(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "None", args: []string{}},
+		{name: "All", args: []string{"--user", "admin", "--password", "secret", "--registry", "ghcr.io"}},
+		{name: "Partial", args: []string{"--password", "secret"}, err: "flags --user, --password and --registry must be used together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := &cobra.Command{
+				Use: "login",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().String("user", "", "username for the registry")
+			cmd.Flags().String("password", "", "password for the registry")
+			cmd.Flags().String("registry", "", "registry to log in to")
+			MarkFlagsRequiredTogether(cmd, "user", "password", "registry")
+			cmd.SetArgs(tt.args)
+
+			err := Execute(cmd, WithStdout(&buf))
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMarkFlagsRequiredTogetherPair(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use: "login",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().String("user", "", "username for the registry")
+	cmd.Flags().String("password", "", "password for the registry")
+	MarkFlagsRequiredTogether(cmd, "user", "password")
+	cmd.SetArgs([]string{"--user", "admin"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.EqualError(t, err, "flags --user and --password must be used together")
+}
+
+func TestMarkFlagsRequiredTogetherUnknownFlagPanics(t *testing.T) {
+	cmd := &cobra.Command{Use: "login"}
+	cmd.Flags().String("user", "", "username for the registry")
+
+	assert.Panics(t, func() {
+		MarkFlagsRequiredTogether(cmd, "user", "password")
+	})
+}
+
+func TestMarkFlagRequiredIf(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{name: "TriggerValue", args: []string{"--backend", "s3"}, err: "flag --bucket is required when --backend is s3"},
+		{name: "OtherValue", args: []string{"--backend", "gcs"}},
+		{name: "Default", args: []string{}},
+		{name: "Satisfied", args: []string{"--backend", "s3", "--bucket", "state"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := &cobra.Command{
+				Use: "init",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().String("backend", "local", "where to store state")
+			cmd.Flags().String("bucket", "", "name of the S3 bucket")
+			MarkFlagRequiredIf(cmd.Flags().Lookup("bucket"), "backend", "s3")
+			cmd.SetArgs(tt.args)
+
+			err := Execute(cmd, WithStdout(&buf))
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMarkFlagRequiredIfNilFlag(_ *testing.T) {
+	MarkFlagRequiredIf(nil, "backend", "s3")
+}
+
+func TestMarkFlagRequiresHiddenFlag(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Bool("check", false, "check for drift")
+	cmd.Flags().Bool("workspace", false, "use workspace")
+	require.NoError(t, cmd.Flags().MarkHidden("workspace"))
+	MarkFlagRequires(cmd.Flags().Lookup("workspace"), "check")
+	cmd.SetArgs([]string{"--workspace"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.EqualError(t, err, "flag --workspace requires --check")
+}
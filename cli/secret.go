@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const secretAnnotation = "purpleclay_cli_secret"
+
+// MarkFlagSecret marks a flag as holding a secret, such as a token or
+// password. The default value of a secret flag is never shown in help, nor
+// is the value of its environment variable. If an invalid value is provided,
+// from the command line, an environment variable or a config file, the error
+// names its source without echoing the value.
+//
+// If flag is nil, MarkFlagSecret silently returns without effect (no-op).
+//
+//	cmd.Flags().StringVar(&token, "token", "", "API token")
+//	cli.BindEnv(cmd.Flags().Lookup("token"), "GITHUB_TOKEN")
+//	cli.MarkFlagSecret(cmd.Flags().Lookup("token"))
+func MarkFlagSecret(flag *pflag.Flag) {
+	if flag == nil {
+		return
+	}
+
+	if flag.Annotations == nil {
+		flag.Annotations = make(map[string][]string)
+	}
+	flag.Annotations[secretAnnotation] = []string{"true"}
+}
+
+// IsFlagSecret reports whether a flag has been marked with [MarkFlagSecret].
+func IsFlagSecret(flag *pflag.Flag) bool {
+	if flag == nil || flag.Annotations == nil {
+		return false
+	}
+	_, ok := flag.Annotations[secretAnnotation]
+	return ok
+}
+
+// addSecretFlagErrors replaces the error cobra reports for an invalid value
+// given to a secret flag on the command line, which would otherwise echo it.
+func addSecretFlagErrors(cmd *cobra.Command) {
+	existing := cmd.FlagErrorFunc()
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		var invalid *pflag.InvalidValueError
+		if errors.As(err, &invalid) && IsFlagSecret(invalid.GetFlag()) {
+			err = fmt.Errorf("invalid value for --%s", invalid.GetFlag().Name)
+		}
+		return existing(c, err)
+	})
+}
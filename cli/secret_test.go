@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "deploy",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().String("token", "s3cr3t-default", "API token")
+	cmd.Flags().Int("pin", 1234, "signing key pin")
+	BindEnv(cmd.Flags().Lookup("token"), "TEST_TOKEN")
+	BindEnv(cmd.Flags().Lookup("pin"), "TEST_PIN")
+	MarkFlagSecret(cmd.Flags().Lookup("token"))
+	MarkFlagSecret(cmd.Flags().Lookup("pin"))
+	return cmd
+}
+
+func TestMarkFlagSecret(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("token", "", "API token")
+	cmd.Flags().String("user", "", "API user")
+
+	MarkFlagSecret(cmd.Flags().Lookup("token"))
+	MarkFlagSecret(nil)
+
+	assert.True(t, IsFlagSecret(cmd.Flags().Lookup("token")))
+	assert.False(t, IsFlagSecret(cmd.Flags().Lookup("user")))
+	assert.False(t, IsFlagSecret(nil))
+}
+
+func TestMarkFlagSecretHidesValuesInHelp(t *testing.T) {
+	t.Setenv("TEST_TOKEN", "s3cr3t-env")
+
+	var buf bytes.Buffer
+	err := Execute(newSecretCmd(), WithStdout(&buf), WithArgs("--help"))
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "--token <string>  [env: TEST_TOKEN]")
+	assert.NotContains(t, buf.String(), "s3cr3t")
+	assert.NotContains(t, buf.String(), "1234")
+}
+
+func TestMarkFlagSecretHidesValuesInErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		args     []string
+		config   string
+		expected string
+	}{
+		{
+			name:     "Environment",
+			env:      "s3cr3t",
+			expected: "invalid value for --pin from environment variable TEST_PIN",
+		},
+		{
+			name:     "CommandLine",
+			args:     []string{"--pin", "s3cr3t"},
+			expected: "invalid value for --pin",
+		},
+		{
+			name:     "ConfigFile",
+			config:   "pin: s3cr3t\n",
+			expected: "invalid value for --pin from config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_PIN", tt.env)
+
+			var stdout, stderr bytes.Buffer
+			opts := []Option{WithStdout(&stdout), WithStderr(&stderr), WithArgs(tt.args...)}
+			if tt.config != "" {
+				opts = append(opts, WithConfigFile(writeConfigFile(t, "config.yaml", tt.config), ""))
+			}
+
+			err := Execute(newSecretCmd(), opts...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.NotContains(t, err.Error(), "s3cr3t")
+			assert.NotContains(t, stderr.String(), "s3cr3t")
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// Exit code used when a second signal forces the CLI to terminate, following
+// the shell convention of 128 + SIGINT.
+const forcedExitCode = 130
+
+// Indirections to support testing signal handling without delivering real
+// signals to the test process.
+var (
+	notifySignals = signal.Notify
+	exit          = os.Exit
+)
+
+// WithSignals cancels the context passed to the command when one of the given
+// signals is received, allowing long-running commands to shut down gracefully
+// by observing cmd.Context().Done(). If no signals are provided, SIGINT and
+// SIGTERM are used. A second signal forces the process to exit immediately
+// with code 130.
+//
+//	cli.Execute(root, cli.WithSignals())
+//
+// Signal handlers are uninstalled when [Execute] returns.
+func WithSignals(sigs ...os.Signal) Option {
+	return func(o *options) {
+		if len(sigs) == 0 {
+			sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+		}
+		o.signals = sigs
+	}
+}
+
+func notifyContext(parent context.Context, sigs ...os.Signal) (context.Context, context.CancelFunc) {
+	ch := make(chan os.Signal, 2)
+	notifySignals(ch, sigs...)
+
+	ctx, stop := handleSignals(parent, ch)
+	return ctx, func() {
+		signal.Stop(ch)
+		stop()
+	}
+}
+
+func handleSignals(parent context.Context, ch <-chan os.Signal) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ch:
+			cancel()
+		case <-done:
+			return
+		}
+
+		select {
+		case <-ch:
+			exit(forcedExitCode)
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
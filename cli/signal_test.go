@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSignalsCancelsContext(t *testing.T) {
+	notify := notifySignals
+	t.Cleanup(func() { notifySignals = notify })
+
+	notifySignals = func(c chan<- os.Signal, _ ...os.Signal) {
+		c <- os.Interrupt
+	}
+
+	var cancelled bool
+
+	cmd := &cobra.Command{
+		Use: "myapp",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			select {
+			case <-cmd.Context().Done():
+				cancelled = true
+			case <-time.After(time.Second):
+			}
+			return nil
+		},
+	}
+
+	var buf bytes.Buffer
+	err := Execute(cmd, WithStdout(&buf), WithSignals())
+
+	require.NoError(t, err)
+	assert.True(t, cancelled)
+}
+
+func TestHandleSignalsSecondSignalExits(t *testing.T) {
+	code := make(chan int, 1)
+
+	osExit := exit
+	t.Cleanup(func() { exit = osExit })
+	exit = func(c int) { code <- c }
+
+	ch := make(chan os.Signal, 2)
+	ctx, stop := handleSignals(context.Background(), ch)
+	defer stop()
+
+	ch <- os.Interrupt
+	<-ctx.Done()
+
+	ch <- os.Interrupt
+	select {
+	case c := <-code:
+		assert.Equal(t, 130, c)
+	case <-time.After(time.Second):
+		t.Fatal("expected forced exit on second signal")
+	}
+}
+
+func TestHandleSignalsStopWithoutSignal(t *testing.T) {
+	ch := make(chan os.Signal, 2)
+	ctx, stop := handleSignals(context.Background(), ch)
+	stop()
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SliceValue implements pflag.Value and pflag.SliceValue for repeatable flags
+// that parse each element into a typed value.
+type SliceValue[T any] struct {
+	parse func(string) (T, error)
+	raw   []string
+	value []T
+}
+
+// SliceVar creates a new flag that parses each element with parse, either as
+// a comma-separated list or by repeating the flag. An element that fails to
+// parse is rejected, naming it in the error.
+//
+//	ports := cli.SliceVar(strconv.Atoi)
+//	cmd.Flags().Var(ports, "port", "ports to listen on")
+//
+// Both --port 80,443 and --port 80 --port 443 result in the same ports.
+func SliceVar[T any](parse func(string) (T, error)) *SliceValue[T] {
+	return &SliceValue[T]{parse: parse}
+}
+
+// String returns the string representation of the current elements.
+func (s *SliceValue[T]) String() string {
+	return "[" + strings.Join(s.raw, ",") + "]"
+}
+
+// Set parses and appends one or more comma-separated elements.
+func (s *SliceValue[T]) Set(val string) error {
+	return s.Append(val)
+}
+
+// Type returns the type name for help output, derived from the element type
+// (e.g., ints for []int). Unnamed element types are shown as values.
+func (s *SliceValue[T]) Type() string {
+	name := reflect.TypeFor[T]().Name()
+	if name == "" {
+		return "values"
+	}
+	return strings.ToLower(name) + "s"
+}
+
+// Append parses and appends one or more comma-separated elements.
+func (s *SliceValue[T]) Append(val string) error {
+	raw := strings.Split(val, ",")
+	parsed, err := s.parseAll(raw)
+	if err != nil {
+		return err
+	}
+	s.raw = append(s.raw, raw...)
+	s.value = append(s.value, parsed...)
+	return nil
+}
+
+// Replace parses and replaces all current elements.
+func (s *SliceValue[T]) Replace(vals []string) error {
+	parsed, err := s.parseAll(vals)
+	if err != nil {
+		return err
+	}
+	s.raw = slices.Clone(vals)
+	s.value = parsed
+	return nil
+}
+
+// GetSlice returns the current elements as they were provided.
+func (s *SliceValue[T]) GetSlice() []string {
+	return slices.Clone(s.raw)
+}
+
+// Get returns the current typed elements.
+func (s *SliceValue[T]) Get() []T {
+	return slices.Clone(s.value)
+}
+
+func (s *SliceValue[T]) parseAll(vals []string) ([]T, error) {
+	parsed := make([]T, 0, len(vals))
+	for _, val := range vals {
+		v, err := s.parse(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %q: %w", val, err)
+		}
+		parsed = append(parsed, v)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSliceVarInts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "CommaSeparated", args: []string{"--port", "80,443"}},
+		{name: "RepeatedFlag", args: []string{"--port", "80", "--port", "443"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ports := SliceVar(strconv.Atoi)
+
+			cmd := &cobra.Command{
+				Use: "test",
+				Run: func(_ *cobra.Command, _ []string) {},
+			}
+			cmd.Flags().Var(ports, "port", "ports to listen on")
+
+			err := Execute(cmd, WithStdout(&buf), WithArgs(tt.args...))
+			require.NoError(t, err)
+			assert.Equal(t, []int{80, 443}, ports.Get())
+			assert.Equal(t, []string{"80", "443"}, ports.GetSlice())
+			assert.Equal(t, "[80,443]", ports.String())
+			assert.Equal(t, "ints", ports.Type())
+		})
+	}
+}
+
+type level struct {
+	name     string
+	priority int
+}
+
+func parseLevel(s string) (level, error) {
+	name, priority, ok := strings.Cut(s, ":")
+	if !ok {
+		return level{}, errors.New("expected name:priority")
+	}
+	p, err := strconv.Atoi(priority)
+	if err != nil {
+		return level{}, err
+	}
+	return level{name: name, priority: p}, nil
+}
+
+func TestSliceVarCustomType(t *testing.T) {
+	levels := SliceVar(parseLevel)
+
+	require.NoError(t, levels.Set("info:1,debug:2"))
+	require.NoError(t, levels.Set("trace:3"))
+	assert.Equal(t, []level{{"info", 1}, {"debug", 2}, {"trace", 3}}, levels.Get())
+	assert.Equal(t, "levels", levels.Type())
+
+	require.NoError(t, levels.Replace([]string{"warn:0"}))
+	assert.Equal(t, []level{{"warn", 0}}, levels.Get())
+	assert.Equal(t, "[warn:0]", levels.String())
+}
+
+func TestSliceVarUnnamedType(t *testing.T) {
+	pairs := SliceVar(func(s string) ([]string, error) { return strings.Split(s, "/"), nil })
+	assert.Equal(t, "values", pairs.Type())
+}
+
+func TestSliceVarSetFailsWithInvalidElement(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	ports := SliceVar(strconv.Atoi)
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(ports, "port", "ports to listen on")
+
+	err := Execute(cmd, WithStdout(&stdout), WithStderr(&stderr), WithArgs("--port", "80", "--port", "443,http"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid element "http": strconv.Atoi: parsing "http": invalid syntax`)
+	assert.Equal(t, []int{80}, ports.Get())
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// StringMapValue implements pflag.Value and pflag.SliceValue for flags that
+// collect key=value pairs into a map.
+type StringMapValue struct {
+	value map[string]string
+}
+
+// StringMap creates a new flag that parses key=value pairs into a map, either
+// as a comma-separated list or by repeating the flag. If a key is given more
+// than once, the last value wins.
+//
+//	labels := cli.StringMap()
+//	cmd.Flags().Var(labels, "label", "add a label to the resource")
+//
+// Both --label env=prod,team=platform and --label env=prod --label team=platform
+// result in the same map.
+func StringMap() *StringMapValue {
+	return &StringMapValue{value: make(map[string]string)}
+}
+
+// String returns the string representation of the current pairs, sorted by key.
+func (m *StringMapValue) String() string {
+	return "[" + strings.Join(m.GetSlice(), ",") + "]"
+}
+
+// Set validates and adds one or more comma-separated key=value pairs.
+func (m *StringMapValue) Set(s string) error {
+	return m.Append(s)
+}
+
+// Type returns the type name for help output.
+func (m *StringMapValue) Type() string {
+	return "key=value"
+}
+
+// Append validates and adds one or more comma-separated key=value pairs.
+func (m *StringMapValue) Append(s string) error {
+	parsed, err := parsePairs(strings.Split(s, ","))
+	if err != nil {
+		return err
+	}
+	maps.Copy(m.value, parsed)
+	return nil
+}
+
+// Replace validates and replaces all current pairs.
+func (m *StringMapValue) Replace(vals []string) error {
+	parsed, err := parsePairs(vals)
+	if err != nil {
+		return err
+	}
+	m.value = parsed
+	return nil
+}
+
+// GetSlice returns the current pairs in key=value form, sorted by key.
+func (m *StringMapValue) GetSlice() []string {
+	pairs := make([]string, 0, len(m.value))
+	for _, k := range slices.Sorted(maps.Keys(m.value)) {
+		pairs = append(pairs, k+"="+m.value[k])
+	}
+	return pairs
+}
+
+// Get returns a copy of the current pairs.
+func (m *StringMapValue) Get() map[string]string {
+	return maps.Clone(m.value)
+}
+
+func parsePairs(vals []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(vals))
+	for _, v := range vals {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("expected key=value, got: %q", v)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
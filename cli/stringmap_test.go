@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringMapCommaSeparated(t *testing.T) {
+	m := StringMap()
+
+	require.NoError(t, m.Set("env=prod,team=platform"))
+	assert.Equal(t, map[string]string{"env": "prod", "team": "platform"}, m.Get())
+	assert.Equal(t, "[env=prod,team=platform]", m.String())
+	assert.Equal(t, "key=value", m.Type())
+}
+
+func TestStringMapRepeatedFlag(t *testing.T) {
+	var buf bytes.Buffer
+	labels := StringMap()
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+	cmd.Flags().Var(labels, "label", "add a label to the resource")
+	cmd.SetArgs([]string{"--label", "env=dev", "--label", "team=platform", "--label", "env=prod"})
+
+	err := Execute(cmd, WithStdout(&buf))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "platform"}, labels.Get())
+}
+
+func TestStringMapAllowsEmptyValue(t *testing.T) {
+	m := StringMap()
+
+	require.NoError(t, m.Set("env="))
+	assert.Equal(t, map[string]string{"env": ""}, m.Get())
+}
+
+func TestStringMapReplace(t *testing.T) {
+	m := StringMap()
+	require.NoError(t, m.Set("env=dev"))
+
+	require.NoError(t, m.Replace([]string{"team=platform"}))
+	assert.Equal(t, map[string]string{"team": "platform"}, m.Get())
+}
+
+func TestStringMapSetFailsWithMalformedEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "MissingSeparator", input: "env"},
+		{name: "MissingKey", input: "=prod"},
+		{name: "MalformedInList", input: "env=prod,team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := StringMap()
+
+			err := m.Set(tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "expected key=value")
+			assert.Empty(t, m.Get())
+		})
+	}
+}
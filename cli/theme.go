@@ -1,10 +1,20 @@
 package cli
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Theme defines the styles used for rendering CLI help output.
 // Each field controls the appearance of a specific element.
 type Theme struct {
+	// Code styles backtick-wrapped code spans within a command's description
+	// (e.g., `nsv tag`).
+	Code lipgloss.Style
+
 	// Command styles command and subcommand names in the COMMANDS section.
 	Command lipgloss.Style
 
@@ -23,15 +33,21 @@ type Theme struct {
 	EnvVarValue lipgloss.Style
 
 	// Flag styles flag names including short and long forms
-	// (e.g., -v, --verbose).
+	// (e.g., -v, --verbose), and flags referenced within a command's
+	// description.
 	Flag lipgloss.Style
 
 	// FlagDefault styles the default value indicator shown beneath flags
 	// (e.g., [default: 8080]).
 	FlagDefault lipgloss.Style
 
-	// FlagType styles the type hint for flags that accept values
-	// (e.g., <string> in --config <string>).
+	// FlagArg styles the value placeholder for flags that accept values
+	// (e.g., <string> in --config <string>). If unset, FlagType is used.
+	FlagArg lipgloss.Style
+
+	// FlagType styles the allowed values of enum flags, both within the type
+	// hint (e.g., <debug|info> in --log-level <debug|info>) and the list of
+	// possible values.
 	FlagType lipgloss.Style
 
 	// Header styles section headings such as USAGE, COMMANDS, FLAGS,
@@ -46,15 +62,146 @@ type Theme struct {
 // DefaultTheme returns a theme with no styling applied.
 func DefaultTheme() Theme {
 	return Theme{
+		Code:        lipgloss.NewStyle(),
 		Command:     lipgloss.NewStyle(),
 		Comment:     lipgloss.NewStyle(),
 		Description: lipgloss.NewStyle(),
 		EnvVar:      lipgloss.NewStyle(),
 		EnvVarValue: lipgloss.NewStyle(),
 		Flag:        lipgloss.NewStyle(),
+		FlagArg:     lipgloss.NewStyle(),
 		FlagDefault: lipgloss.NewStyle(),
 		FlagType:    lipgloss.NewStyle(),
 		Header:      lipgloss.NewStyle(),
 		Operator:    lipgloss.NewStyle(),
 	}
 }
+
+// WithCode returns a copy of the theme with the style of code spans within a
+// command's description replaced. Each field of [Theme] has an equivalent
+// builder, allowing a few styles to be changed without restating the rest.
+//
+//	theme := cli.DefaultTheme().
+//	    WithHeader(lipgloss.NewStyle().Bold(true)).
+//	    WithFlag(lipgloss.NewStyle().Foreground(lipgloss.Color("141")))
+func (t Theme) WithCode(style lipgloss.Style) Theme {
+	t.Code = style
+	return t
+}
+
+// WithCommand returns a copy of the theme with the style of command names
+// replaced.
+func (t Theme) WithCommand(style lipgloss.Style) Theme {
+	t.Command = style
+	return t
+}
+
+// WithComment returns a copy of the theme with the style of comments within
+// examples replaced.
+func (t Theme) WithComment(style lipgloss.Style) Theme {
+	t.Comment = style
+	return t
+}
+
+// WithDescription returns a copy of the theme with the style of help text of
+// commands and flags replaced.
+func (t Theme) WithDescription(style lipgloss.Style) Theme {
+	t.Description = style
+	return t
+}
+
+// WithEnvVar returns a copy of the theme with the style of environment variable
+// names replaced.
+func (t Theme) WithEnvVar(style lipgloss.Style) Theme {
+	t.EnvVar = style
+	return t
+}
+
+// WithEnvVarValue returns a copy of the theme with the style of environment
+// variable values replaced.
+func (t Theme) WithEnvVarValue(style lipgloss.Style) Theme {
+	t.EnvVarValue = style
+	return t
+}
+
+// WithFlag returns a copy of the theme with the style of flag names replaced.
+func (t Theme) WithFlag(style lipgloss.Style) Theme {
+	t.Flag = style
+	return t
+}
+
+// WithFlagArg returns a copy of the theme with the style of flag value
+// placeholders replaced.
+func (t Theme) WithFlagArg(style lipgloss.Style) Theme {
+	t.FlagArg = style
+	return t
+}
+
+// WithFlagDefault returns a copy of the theme with the style of flag default
+// values replaced.
+func (t Theme) WithFlagDefault(style lipgloss.Style) Theme {
+	t.FlagDefault = style
+	return t
+}
+
+// WithFlagType returns a copy of the theme with the style of the allowed values
+// of enum flags replaced.
+func (t Theme) WithFlagType(style lipgloss.Style) Theme {
+	t.FlagType = style
+	return t
+}
+
+// WithHeader returns a copy of the theme with the style of section headings
+// replaced.
+func (t Theme) WithHeader(style lipgloss.Style) Theme {
+	t.Header = style
+	return t
+}
+
+// WithOperator returns a copy of the theme with the style of shell operators
+// within examples replaced.
+func (t Theme) WithOperator(style lipgloss.Style) Theme {
+	t.Operator = style
+	return t
+}
+
+// Validate reports an error listing any styles that have been left unset,
+// which is common when a theme is only partially constructed.
+//
+//	if err := theme.Validate(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (t Theme) Validate() error {
+	var missing []string
+
+	v := reflect.ValueOf(t)
+	for i := range v.NumField() {
+		if v.Field(i).IsZero() {
+			missing = append(missing, v.Type().Field(i).Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("theme has unset styles: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// withDefaults returns a copy of the theme with any unset styles replaced
+// by those from [DefaultTheme]. An unset FlagArg falls back to FlagType,
+// which styled value placeholders before FlagArg was introduced.
+func (t Theme) withDefaults() Theme {
+	if reflect.ValueOf(t.FlagArg).IsZero() {
+		t.FlagArg = t.FlagType
+	}
+
+	def := reflect.ValueOf(DefaultTheme())
+
+	v := reflect.ValueOf(&t).Elem()
+	for i := range v.NumField() {
+		if v.Field(i).IsZero() {
+			v.Field(i).Set(def.Field(i))
+		}
+	}
+	return t
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThemeValidate(t *testing.T) {
+	require.NoError(t, DefaultTheme().Validate())
+}
+
+func TestThemeValidateMissingStyles(t *testing.T) {
+	theme := Theme{
+		Command: lipgloss.NewStyle().Bold(true),
+		Flag:    lipgloss.NewStyle().Bold(true),
+	}
+
+	err := theme.Validate()
+	require.EqualError(t, err, "theme has unset styles: Code, Comment, Description, EnvVar, EnvVarValue, FlagDefault, FlagArg, FlagType, Header, Operator")
+}
+
+func TestWithThemeFillsMissingStyles(t *testing.T) {
+	theme := DefaultTheme()
+	theme.Header = lipgloss.NewStyle().Bold(true)
+	theme.FlagArg = lipgloss.Style{}
+
+	o := defaultOptions()
+	WithTheme(theme)(o)
+
+	require.NoError(t, o.theme.Validate())
+	assert.Equal(t, DefaultTheme().FlagArg, o.theme.FlagArg)
+	assert.Equal(t, theme.Header, o.theme.Header)
+}
+
+func TestWithThemeFlagArgFallsBackToFlagType(t *testing.T) {
+	flagType := lipgloss.NewStyle().Foreground(lipgloss.Color("5"))
+
+	o := defaultOptions()
+	WithTheme(Theme{FlagType: flagType})(o)
+
+	assert.Equal(t, flagType, o.theme.FlagArg)
+}
+
+func TestThemeBuilders(t *testing.T) {
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("201"))
+
+	tests := []struct {
+		name  string
+		build func(Theme) Theme
+	}{
+		{name: "Code", build: func(t Theme) Theme { return t.WithCode(style) }},
+		{name: "Command", build: func(t Theme) Theme { return t.WithCommand(style) }},
+		{name: "Comment", build: func(t Theme) Theme { return t.WithComment(style) }},
+		{name: "Description", build: func(t Theme) Theme { return t.WithDescription(style) }},
+		{name: "EnvVar", build: func(t Theme) Theme { return t.WithEnvVar(style) }},
+		{name: "EnvVarValue", build: func(t Theme) Theme { return t.WithEnvVarValue(style) }},
+		{name: "Flag", build: func(t Theme) Theme { return t.WithFlag(style) }},
+		{name: "FlagArg", build: func(t Theme) Theme { return t.WithFlagArg(style) }},
+		{name: "FlagDefault", build: func(t Theme) Theme { return t.WithFlagDefault(style) }},
+		{name: "FlagType", build: func(t Theme) Theme { return t.WithFlagType(style) }},
+		{name: "Header", build: func(t Theme) Theme { return t.WithHeader(style) }},
+		{name: "Operator", build: func(t Theme) Theme { return t.WithOperator(style) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := DefaultTheme()
+			theme := tt.build(original)
+
+			assert.Equal(t, DefaultTheme(), original)
+
+			expected := DefaultTheme()
+			reflect.ValueOf(&expected).Elem().FieldByName(tt.name).Set(reflect.ValueOf(style))
+			assert.Equal(t, expected, theme)
+		})
+	}
+}
+
+func TestThemeBuildersChained(t *testing.T) {
+	header := lipgloss.NewStyle().Bold(true).Underline(true)
+	flag := lipgloss.NewStyle().Foreground(lipgloss.Color("201"))
+
+	theme := DefaultTheme().WithHeader(header).WithFlag(flag)
+
+	require.NoError(t, theme.Validate())
+	assert.Equal(t, header, theme.Header)
+	assert.Equal(t, flag, theme.Flag)
+	assert.Equal(t, DefaultTheme().Command, theme.Command)
+}
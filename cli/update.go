@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultUpdateTTL     = 24 * time.Hour
+	defaultUpdateTimeout = 2 * time.Second
+
+	// updateWait bounds how long a command that has already finished waits
+	// for an update check that is still in flight.
+	updateWait = 250 * time.Millisecond
+)
+
+// UpdateConfig configures the update check enabled by [WithUpdateCheck].
+type UpdateConfig struct {
+	// Current is the semantic version of the running binary (e.g., v1.2.0).
+	// The check is skipped if it is empty or not a semantic version.
+	Current string
+
+	// Repository is the GitHub repository, in the form owner/name, whose
+	// latest release is checked.
+	Repository string
+
+	// URL overrides the endpoint queried for the latest release. It must
+	// respond with a JSON object containing the release's tag_name, like the
+	// GitHub releases API.
+	URL string
+
+	// TTL is how long the result of a check is cached before querying the
+	// endpoint again. Defaults to 24 hours.
+	TTL time.Duration
+
+	// CacheFile is the path of the file caching the result of a check.
+	// Defaults to update-check.json within a directory named after the root
+	// command, under the user's cache directory.
+	CacheFile string
+
+	// Client is the HTTP client used to query the endpoint. Defaults to a
+	// client with a timeout of two seconds.
+	Client *http.Client
+}
+
+// WithUpdateCheck checks for a newer release of the CLI while a command runs.
+// If the command succeeds and a newer version is found, a notice is printed
+// to stderr. The check never fails or delays a command beyond a brief wait,
+// with any error being silently ignored. Hidden commands, such as those used
+// by shell completion, are never checked.
+//
+//	cli.Execute(root, cli.WithUpdateCheck(cli.UpdateConfig{
+//	    Current:    version,
+//	    Repository: "purpleclay/nsv",
+//	}))
+func WithUpdateCheck(cfg UpdateConfig) Option {
+	return func(o *options) {
+		o.updateCheck = &cfg
+	}
+}
+
+type updateCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+type updateCheck struct {
+	cfg    UpdateConfig
+	name   string
+	latest chan string
+}
+
+func addUpdateCheck(cmd *cobra.Command, cfg UpdateConfig) *updateCheck {
+	if cfg.URL == "" && cfg.Repository != "" {
+		cfg.URL = "https://api.github.com/repos/" + cfg.Repository + "/releases/latest"
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultUpdateTTL
+	}
+	if cfg.CacheFile == "" {
+		if dir, err := os.UserCacheDir(); err == nil {
+			cfg.CacheFile = filepath.Join(dir, cmd.Name(), "update-check.json")
+		}
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: defaultUpdateTimeout}
+	}
+
+	check := &updateCheck{cfg: cfg, name: cmd.Name()}
+	wrapPersistentPreRun(cmd, func(c *cobra.Command, _ []string) error {
+		if c.Hidden || cfg.URL == "" {
+			return nil
+		}
+		if _, ok := parseSemver(cfg.Current); !ok {
+			return nil
+		}
+
+		check.latest = make(chan string, 1)
+		go func() {
+			check.latest <- check.latestVersion(c.Context())
+		}()
+		return nil
+	})
+	return check
+}
+
+// notify prints a notice to w if the check found a newer version. It waits
+// briefly for a check still in flight, giving up rather than delaying exit.
+func (u *updateCheck) notify(w io.Writer, theme Theme) {
+	if u.latest == nil {
+		return
+	}
+
+	var latest string
+	select {
+	case latest = <-u.latest:
+	case <-time.After(updateWait):
+		return
+	}
+
+	if compareSemver(latest, u.cfg.Current) <= 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s %s %s %s\n",
+		theme.Description.Render("A new version of"),
+		theme.Command.Render(u.name),
+		theme.Description.Render("is available:"),
+		theme.FlagDefault.Render(u.cfg.Current+" → "+latest))
+	if u.cfg.Repository != "" {
+		fmt.Fprintln(w, theme.Description.Render("https://github.com/"+u.cfg.Repository+"/releases/latest"))
+	}
+}
+
+// latestVersion returns the latest released version, from the cache if it
+// has not expired. An empty string is returned if the check fails.
+func (u *updateCheck) latestVersion(ctx context.Context) string {
+	if cache, ok := u.readCache(); ok && time.Since(cache.CheckedAt) < u.cfg.TTL {
+		return cache.Latest
+	}
+
+	latest, err := u.fetchLatest(ctx)
+	if err != nil {
+		return ""
+	}
+
+	u.writeCache(updateCache{CheckedAt: time.Now(), Latest: latest})
+	return latest
+}
+
+func (u *updateCheck) fetchLatest(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.cfg.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := u.cfg.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+func (u *updateCheck) readCache() (updateCache, bool) {
+	var cache updateCache
+	if u.cfg.CacheFile == "" {
+		return cache, false
+	}
+
+	data, err := os.ReadFile(u.cfg.CacheFile)
+	if err != nil {
+		return cache, false
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return cache, false
+	}
+	return cache, true
+}
+
+func (u *updateCheck) writeCache(cache updateCache) {
+	if u.cfg.CacheFile == "" {
+		return
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(u.cfg.CacheFile), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(u.cfg.CacheFile, data, 0o644)
+}
+
+type semver struct {
+	parts      [3]int
+	prerelease []string
+}
+
+// parseSemver parses a semantic version of the form major.minor.patch, with or
+// without a leading v. Any build metadata is ignored.
+func parseSemver(s string) (semver, bool) {
+	var v semver
+
+	s = strings.TrimPrefix(s, "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, prerelease, hasPrerelease := strings.Cut(s, "-")
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		if !isNumeric(part) {
+			return v, false
+		}
+		v.parts[i], _ = strconv.Atoi(part)
+	}
+
+	if hasPrerelease {
+		v.prerelease = strings.Split(prerelease, ".")
+		if slices.Contains(v.prerelease, "") {
+			return v, false
+		}
+	}
+	return v, true
+}
+
+// compareSemver returns 1 if a is newer than b, -1 if it is older and 0 if
+// they are equal. A version that cannot be parsed is never newer.
+func compareSemver(a, b string) int {
+	va, ok := parseSemver(a)
+	if !ok {
+		return -1
+	}
+	vb, ok := parseSemver(b)
+	if !ok {
+		return 1
+	}
+
+	for i := range va.parts {
+		if c := cmp.Compare(va.parts[i], vb.parts[i]); c != 0 {
+			return c
+		}
+	}
+
+	// A release is newer than any of its pre-releases
+	switch {
+	case len(va.prerelease) == 0 && len(vb.prerelease) == 0:
+		return 0
+	case len(va.prerelease) == 0:
+		return 1
+	case len(vb.prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(va.prerelease) && i < len(vb.prerelease); i++ {
+		if c := comparePrereleaseIdentifier(va.prerelease[i], vb.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(va.prerelease), len(vb.prerelease))
+}
+
+// comparePrereleaseIdentifier compares numeric identifiers numerically, which
+// are always older than alphanumeric identifiers, compared lexically.
+func comparePrereleaseIdentifier(a, b string) int {
+	numericA, numericB := isNumeric(a), isNumeric(b)
+	switch {
+	case numericA && numericB:
+		// Compared by length first, as identifiers can exceed an int
+		a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+		return cmp.Or(cmp.Compare(len(a), len(b)), strings.Compare(a, b))
+	case numericA:
+		return -1
+	case numericB:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func isNumeric(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
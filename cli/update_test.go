@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func releaseClient(t *testing.T, tag string) *http.Client {
+	t.Helper()
+
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://api.github.com/repos/purpleclay/nsv/releases/latest", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"tag_name":"` + tag + `"}`)),
+		}, nil
+	})}
+}
+
+func executeWithUpdateCheck(t *testing.T, cfg UpdateConfig) string {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{
+		Use: "nsv",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+
+	err := Execute(cmd, WithStdout(&stdout), WithStderr(&stderr), WithUpdateCheck(cfg), WithArgs())
+	require.NoError(t, err)
+	return stderr.String()
+}
+
+func TestWithUpdateCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		latest   string
+		expected string
+	}{
+		{
+			name:   "Newer",
+			latest: "v1.3.0",
+			expected: `
+A new version of nsv is available: v1.2.0 → v1.3.0
+https://github.com/purpleclay/nsv/releases/latest
+`,
+		},
+		{
+			name:     "Older",
+			latest:   "v1.1.9",
+			expected: "",
+		},
+		{
+			name:     "Equal",
+			latest:   "v1.2.0",
+			expected: "",
+		},
+		{
+			name:     "PreRelease",
+			latest:   "v1.3.0-rc.1",
+			expected: "\nA new version of nsv is available: v1.2.0 → v1.3.0-rc.1\nhttps://github.com/purpleclay/nsv/releases/latest\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := filepath.Join(t.TempDir(), "nsv", "update-check.json")
+
+			stderr := executeWithUpdateCheck(t, UpdateConfig{
+				Current:    "v1.2.0",
+				Repository: "purpleclay/nsv",
+				CacheFile:  cache,
+				Client:     releaseClient(t, tt.latest),
+			})
+			assert.Equal(t, tt.expected, stderr)
+
+			data, err := os.ReadFile(cache)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"latest":"`+tt.latest+`"`)
+		})
+	}
+}
+
+func TestWithUpdateCheckCacheHit(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "update-check.json")
+	data, err := json.Marshal(updateCache{CheckedAt: time.Now(), Latest: "v2.0.0"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache, data, 0o600))
+
+	stderr := executeWithUpdateCheck(t, UpdateConfig{
+		Current:    "v1.2.0",
+		Repository: "purpleclay/nsv",
+		CacheFile:  cache,
+		Client: &http.Client{Transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+			t.Fatal("unexpected request for a cached check")
+			return nil, nil
+		})},
+	})
+	assert.Contains(t, stderr, "v1.2.0 → v2.0.0")
+}
+
+func TestWithUpdateCheckCacheExpired(t *testing.T) {
+	cache := filepath.Join(t.TempDir(), "update-check.json")
+	data, err := json.Marshal(updateCache{CheckedAt: time.Now().Add(-2 * time.Hour), Latest: "v2.0.0"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache, data, 0o600))
+
+	stderr := executeWithUpdateCheck(t, UpdateConfig{
+		Current:    "v1.2.0",
+		Repository: "purpleclay/nsv",
+		TTL:        time.Hour,
+		CacheFile:  cache,
+		Client:     releaseClient(t, "v1.2.0"),
+	})
+	assert.Empty(t, stderr)
+}
+
+func TestWithUpdateCheckFailsSilently(t *testing.T) {
+	tests := []struct {
+		name      string
+		transport roundTripFunc
+	}{
+		{
+			name: "RequestError",
+			transport: func(_ *http.Request) (*http.Response, error) {
+				return nil, errors.New("network unreachable")
+			},
+		},
+		{
+			name: "UnexpectedStatus",
+			transport: func(_ *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusForbidden,
+					Body:       io.NopCloser(strings.NewReader(`{"message":"rate limited"}`)),
+				}, nil
+			},
+		},
+		{
+			name: "InvalidResponse",
+			transport: func(_ *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`not json`)),
+				}, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := filepath.Join(t.TempDir(), "update-check.json")
+
+			stderr := executeWithUpdateCheck(t, UpdateConfig{
+				Current:    "v1.2.0",
+				Repository: "purpleclay/nsv",
+				CacheFile:  cache,
+				Client:     &http.Client{Transport: tt.transport},
+			})
+			assert.Empty(t, stderr)
+			assert.NoFileExists(t, cache)
+		})
+	}
+}
+
+func TestWithUpdateCheckSkippedOnError(t *testing.T) {
+	// The check is never awaited, so it must not write to the cache file once
+	// the test has finished and its directory is being removed. A cached result
+	// is found without a request, and a failed request is never cached
+	cache := filepath.Join(t.TempDir(), "update-check.json")
+	data, err := json.Marshal(updateCache{CheckedAt: time.Now(), Latest: "v1.3.0"})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cache, data, 0o600))
+
+	var stdout, stderr bytes.Buffer
+	cmd := &cobra.Command{
+		Use: "nsv",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return errors.New("boom")
+		},
+	}
+
+	err = Execute(cmd, WithStdout(&stdout), WithStderr(&stderr), WithSilenceErrors(true), WithArgs(),
+		WithUpdateCheck(UpdateConfig{
+			Current:    "v1.2.0",
+			Repository: "purpleclay/nsv",
+			CacheFile:  cache,
+			Client: &http.Client{Transport: roundTripFunc(func(_ *http.Request) (*http.Response, error) {
+				return nil, errors.New("offline")
+			})},
+		}))
+	require.Error(t, err)
+	assert.Empty(t, stderr.String())
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "v1.2.0", b: "v1.2.0", expected: 0},
+		{a: "1.2.0", b: "v1.2.0", expected: 0},
+		{a: "v1.10.0", b: "v1.9.0", expected: 1},
+		{a: "v1.2.0", b: "v2.0.0", expected: -1},
+		{a: "v1.2.0", b: "v1.2.0-rc.1", expected: 1},
+		{a: "v1.2.0-beta", b: "v1.2.0-alpha", expected: 1},
+		{a: "v1.2.0+build", b: "v1.2.0", expected: 0},
+		{a: "v1.3.0-rc.10", b: "v1.3.0-rc.9", expected: 1},
+		{a: "v1.3.0-rc.9", b: "v1.3.0-rc.10", expected: -1},
+		{a: "v1.3.0-rc.1", b: "v1.3.0-rc.1.1", expected: -1},
+		{a: "v1.3.0-rc.1", b: "v1.3.0-rc.beta", expected: -1},
+		{a: "v1.3.0-alpha.1", b: "v1.3.0-alpha.1", expected: 0},
+		{a: "latest", b: "v1.2.0", expected: -1},
+		{a: "v1.2", b: "v1.1.0", expected: -1},
+		{a: "v1.2.0", b: "v1.2", expected: 1},
+		{a: "v1.2.+1", b: "v1.2.0", expected: -1},
+		{a: "v1.2.0-", b: "v1.1.0", expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, compareSemver(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// VerbosityQuiet is the verbosity level reported by [Verbosity] when --quiet
+// is provided. It is always lower than any other level.
+const VerbosityQuiet = -1
+
+const (
+	verboseFlag = "verbose"
+	quietFlag   = "quiet"
+)
+
+// WithVerbosity adds a repeatable -v/--verbose flag and a -q/--quiet flag to
+// the root command, inherited by every subcommand. The two flags conflict with
+// each other. Use [Verbosity] to resolve the requested level.
+//
+//	cli.Execute(root, cli.WithVerbosity())
+func WithVerbosity() Option {
+	return func(o *options) {
+		o.verbosity = true
+	}
+}
+
+func addVerbosityFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().CountP(verboseFlag, "v", "increase output verbosity, repeat for more detail")
+	cmd.PersistentFlags().BoolP(quietFlag, "q", false, "only output errors")
+	MarkFlagConflicts(cmd.PersistentFlags().Lookup(quietFlag), verboseFlag)
+}
+
+// Verbosity returns the verbosity level requested through the flags added by
+// [WithVerbosity]. The level is 0 by default and increases by one for every
+// -v provided, so -vv yields 2. If --quiet is provided, [VerbosityQuiet] is
+// returned.
+//
+// The logger configured by [WithLogger] follows the level automatically. For
+// any other logger, it can be used to step through the values of an enum log
+// level, falling back to it when neither flag is provided:
+//
+//	levels := []string{"error", "info", "debug", "trace"}
+//	if cmd.Flags().Changed("verbose") || cmd.Flags().Changed("quiet") {
+//	    level := levels[min(cli.Verbosity(cmd)+1, len(levels)-1)]
+//	    logLevel.Set(level)
+//	}
+func Verbosity(cmd *cobra.Command) int {
+	if quiet, err := cmd.Flags().GetBool(quietFlag); err == nil && quiet {
+		return VerbosityQuiet
+	}
+
+	verbose, _ := cmd.Flags().GetCount(verboseFlag)
+	return verbose
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbosity(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "Default", args: []string{"sub"}, expected: 0},
+		{name: "Verbose", args: []string{"sub", "-v"}, expected: 1},
+		{name: "Repeated", args: []string{"sub", "-vv"}, expected: 2},
+		{name: "LongForm", args: []string{"--verbose", "sub", "--verbose", "--verbose"}, expected: 3},
+		{name: "Quiet", args: []string{"sub", "--quiet"}, expected: VerbosityQuiet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var level int
+
+			root := &cobra.Command{Use: "test"}
+			root.AddCommand(&cobra.Command{
+				Use: "sub",
+				Run: func(cmd *cobra.Command, _ []string) {
+					level = Verbosity(cmd)
+				},
+			})
+
+			err := Execute(root, WithStdout(&buf), WithVerbosity(), WithArgs(tt.args...))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+}
+
+func TestVerbosityQuietConflictsWithVerbose(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {},
+	}
+
+	err := Execute(cmd, WithStdout(&buf), WithVerbosity(), WithArgs("-q", "-v"))
+	require.EqualError(t, err, "flag --quiet conflicts with --verbose")
+}
+
+func TestVerbosityWithoutFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	assert.Equal(t, 0, Verbosity(cmd))
+}
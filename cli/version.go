@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"runtime/debug"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 )
@@ -26,10 +28,52 @@ type VersionInfo struct {
 	// GoVersion is the Go version used to build the binary.
 	GoVersion string `json:"go_version,omitempty"`
 
-	// Platform is the OS/architecture the binary was built for.
+	// Platform is the OS/architecture the binary was built for. It is
+	// automatically populated from runtime.GOOS/GOARCH if not set.
 	Platform string `json:"platform,omitempty"`
 }
 
+var readBuildInfo = debug.ReadBuildInfo
+
+// VersionFromBuildInfo fills any empty fields of info from the build
+// information embedded by the Go toolchain, useful when a binary is built
+// without injecting version details through ldflags. Values already set
+// within info are always preserved.
+//
+//	cli.Execute(root,
+//	    cli.WithVersionCommand(cli.VersionFromBuildInfo(cli.VersionInfo{
+//	        GitBranch: branch,
+//	    })),
+//	)
+func VersionFromBuildInfo(info VersionInfo) VersionInfo {
+	bi, ok := readBuildInfo()
+	if !ok {
+		return info
+	}
+
+	if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		info.Version = bi.Main.Version
+	}
+	if info.GoVersion == "" {
+		info.GoVersion = bi.GoVersion
+	}
+
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.GitCommit == "" {
+				info.GitCommit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		}
+	}
+
+	return info
+}
+
 func renderVersion(info *VersionInfo, theme Theme) string {
 	var buf strings.Builder
 
@@ -77,20 +121,65 @@ func renderVersion(info *VersionInfo, theme Theme) string {
 	return buf.String()
 }
 
+func renderVersionTemplate(tmpl *template.Template, info *VersionInfo) (string, error) {
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, info); err != nil {
+		return "", fmt.Errorf("rendering version template: %w", err)
+	}
+	return buf.String(), nil
+}
+
 func renderVersionShort(w io.Writer, info *VersionInfo) {
 	fmt.Fprintln(w, info.Version)
 }
 
-func renderVersionJSON(w io.Writer, info *VersionInfo) error {
+// versionModule describes a Go module compiled into the binary.
+type versionModule struct {
+	Path    string `json:"path"`
+	Version string `json:"version,omitempty"`
+	Sum     string `json:"sum,omitempty"`
+}
+
+func newVersionModule(mod *debug.Module) versionModule {
+	// Report the module that was actually compiled into the binary
+	if mod.Replace != nil {
+		mod = mod.Replace
+	}
+	return versionModule{Path: mod.Path, Version: mod.Version, Sum: mod.Sum}
+}
+
+func renderVersionJSON(w io.Writer, info *VersionInfo, deps bool) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(info)
+	if !deps {
+		return encoder.Encode(info)
+	}
+
+	out := struct {
+		*VersionInfo
+		Module       *versionModule  `json:"module,omitempty"`
+		Dependencies []versionModule `json:"dependencies"`
+	}{
+		VersionInfo:  info,
+		Dependencies: []versionModule{},
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		mod := newVersionModule(&bi.Main)
+		out.Module = &mod
+		for _, dep := range bi.Deps {
+			out.Dependencies = append(out.Dependencies, newVersionModule(dep))
+		}
+	}
+
+	return encoder.Encode(out)
 }
 
-func newVersionCommand(info *VersionInfo, theme Theme) *cobra.Command {
+func newVersionCommand(info *VersionInfo, theme Theme, tmpl *template.Template) *cobra.Command {
 	var (
 		short   bool
 		jsonOut bool
+		deps    bool
 	)
 
 	cmd := &cobra.Command{
@@ -99,12 +188,20 @@ func newVersionCommand(info *VersionInfo, theme Theme) *cobra.Command {
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if jsonOut {
-				return renderVersionJSON(cmd.OutOrStdout(), info)
+				return renderVersionJSON(cmd.OutOrStdout(), info, deps)
 			}
 			if short {
 				renderVersionShort(cmd.OutOrStdout(), info)
 				return nil
 			}
+			if tmpl != nil {
+				version, err := renderVersionTemplate(tmpl, info)
+				if err != nil {
+					return err
+				}
+				fmt.Fprint(cmd.OutOrStdout(), version)
+				return nil
+			}
 			fmt.Fprint(cmd.OutOrStdout(), renderVersion(info, theme))
 			return nil
 		},
@@ -112,7 +209,9 @@ func newVersionCommand(info *VersionInfo, theme Theme) *cobra.Command {
 
 	cmd.Flags().BoolVar(&short, "short", false, "display only the version number")
 	cmd.Flags().BoolVar(&jsonOut, "json", false, "display version information as JSON")
+	cmd.Flags().BoolVar(&deps, "deps", false, "include Go module dependencies in the JSON output")
 	cmd.MarkFlagsMutuallyExclusive("short", "json")
+	MarkFlagRequires(cmd.Flags().Lookup("deps"), "json")
 
 	return cmd
 }
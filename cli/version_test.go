@@ -2,9 +2,13 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
+	"runtime"
+	"runtime/debug"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gotest.tools/v3/golden"
 )
@@ -24,6 +28,7 @@ func testVersionInfo() VersionInfo {
 		GitBranch: "main",
 		BuildDate: "2024-01-15T10:30:00Z",
 		GoVersion: "go1.21.0",
+		Platform:  "linux/amd64",
 	}
 }
 
@@ -114,7 +119,57 @@ func TestVersionMinimal(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	golden.Assert(t, buf.String(), "version_minimal.golden")
+	// Platform is populated from the runtime when left blank
+	expected := "0.1.0\n\nBUILD INFORMATION\n\nPlatform      " + runtime.GOOS + "/" + runtime.GOARCH + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestVersionPlatformDefaultsToRuntime(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--json"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionCommand(VersionInfo{Version: "0.1.0"}),
+	)
+	require.NoError(t, err)
+
+	var info VersionInfo
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &info))
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+}
+
+func TestVersionPlatformPreserved(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"--version"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionFlag(VersionInfo{Version: "0.1.0", Platform: "plan9/arm"}),
+	)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "plan9/arm")
+	assert.NotContains(t, buf.String(), runtime.GOOS+"/"+runtime.GOARCH)
+}
+
+func TestVersionShortOmitsPlatform(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--short"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionCommand(VersionInfo{Version: "0.1.0"}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.1.0\n", buf.String())
 }
 
 func TestHelpWithVersionFlag(t *testing.T) {
@@ -146,3 +201,222 @@ func TestHelpWithVersionCommand(t *testing.T) {
 
 	golden.Assert(t, buf.String(), "help_with_version_command.golden")
 }
+
+func TestVersionFromBuildInfo(t *testing.T) {
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.24.0",
+			Main:      debug.Module{Version: "v1.0.0"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "def5678"},
+				{Key: "vcs.time", Value: "2025-01-15T10:30:00Z"},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+
+	tests := []struct {
+		name     string
+		info     VersionInfo
+		expected VersionInfo
+	}{
+		{
+			name: "Empty",
+			info: VersionInfo{},
+			expected: VersionInfo{
+				Version:   "v1.0.0",
+				GitCommit: "def5678",
+				BuildDate: "2025-01-15T10:30:00Z",
+				GoVersion: "go1.24.0",
+			},
+		},
+		{
+			name: "CallerValuesWin",
+			info: VersionInfo{Version: "1.2.3", GitCommit: "abc1234", GitBranch: "main"},
+			expected: VersionInfo{
+				Version:   "1.2.3",
+				GitCommit: "abc1234",
+				GitBranch: "main",
+				BuildDate: "2025-01-15T10:30:00Z",
+				GoVersion: "go1.24.0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, VersionFromBuildInfo(tt.info))
+		})
+	}
+}
+
+func TestVersionFromBuildInfoSkipsDevelVersion(t *testing.T) {
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.24.0",
+			Main:      debug.Module{Version: "(devel)"},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+
+	info := VersionFromBuildInfo(VersionInfo{})
+	assert.Empty(t, info.Version)
+	assert.Equal(t, "go1.24.0", info.GoVersion)
+}
+
+func TestVersionFromBuildInfoPopulatesGoVersion(t *testing.T) {
+	info := VersionFromBuildInfo(VersionInfo{})
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestVersionTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		opt  Option
+	}{
+		{name: "Flag", args: []string{"--version"}, opt: WithVersionFlag(testVersionInfo())},
+		{name: "Command", args: []string{"version"}, opt: WithVersionCommand(testVersionInfo())},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := newVersionTestCmd()
+			cmd.SetArgs(tt.args)
+
+			err := Execute(cmd,
+				WithStdout(&buf),
+				tt.opt,
+				WithVersionTemplate("{{.Version}} ({{.GitCommit}}) {{.Platform}}\n"),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, "1.2.3 (abc1234) linux/amd64\n", buf.String())
+		})
+	}
+}
+
+func TestVersionTemplateBypassedByOutputFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   string
+		golden string
+	}{
+		{name: "Short", flag: "--short", golden: "version_short.golden"},
+		{name: "JSON", flag: "--json", golden: "version_json.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			cmd := newVersionTestCmd()
+			cmd.SetArgs([]string{"version", tt.flag})
+
+			err := Execute(cmd,
+				WithStdout(&buf),
+				WithVersionCommand(testVersionInfo()),
+				WithVersionTemplate("custom {{.Version}}\n"),
+			)
+			require.NoError(t, err)
+
+			golden.Assert(t, buf.String(), tt.golden)
+		})
+	}
+}
+
+func TestVersionTemplateInvalid(t *testing.T) {
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version"})
+
+	err := Execute(cmd,
+		WithStdout(&bytes.Buffer{}),
+		WithVersionCommand(testVersionInfo()),
+		WithVersionTemplate("{{.Version"),
+	)
+	require.ErrorContains(t, err, "invalid version template")
+}
+
+func TestVersionCommandJSONDeps(t *testing.T) {
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "github.com/purpleclay/myapp", Version: "v1.2.3"},
+			Deps: []*debug.Module{
+				{Path: "github.com/spf13/cobra", Version: "v1.8.0", Sum: "h1:cobra="},
+				{
+					Path:    "github.com/spf13/pflag",
+					Version: "v1.0.5",
+					Replace: &debug.Module{Path: "github.com/fork/pflag", Version: "v1.0.6", Sum: "h1:pflag="},
+				},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--json", "--deps"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionCommand(testVersionInfo()),
+	)
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "version_json_deps.golden")
+}
+
+func TestVersionCommandJSONDepsWithoutBuildInfo(t *testing.T) {
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--json", "--deps"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionCommand(testVersionInfo()),
+	)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, []any{}, out["dependencies"])
+	assert.NotContains(t, out, "module")
+}
+
+func TestVersionCommandJSONOmitsDepsByDefault(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--json"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithVersionCommand(testVersionInfo()),
+	)
+	require.NoError(t, err)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.NotContains(t, out, "dependencies")
+}
+
+func TestVersionCommandDepsRequiresJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	cmd := newVersionTestCmd()
+	cmd.SetArgs([]string{"version", "--deps"})
+
+	err := Execute(cmd,
+		WithStdout(&buf),
+		WithStderr(&buf),
+		WithVersionCommand(testVersionInfo()),
+	)
+	require.EqualError(t, err, "flag --deps requires --json")
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+// defaultHelpWidth is the width help output is wrapped to when it cannot be
+// detected from the terminal or the COLUMNS environment variable.
+const defaultHelpWidth = 80
+
+// helpWidth resolves the width to wrap help output written to w. The width of
+// the terminal is used when w is attached to one, falling back to the COLUMNS
+// environment variable and then [defaultHelpWidth].
+func helpWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok && isTerminal(f) {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultHelpWidth
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gotest.tools/v3/golden"
+)
+
+func TestMain(m *testing.M) {
+	// Help goldens are rendered at the default width, which must not depend
+	// on the COLUMNS of the shell running the tests
+	os.Unsetenv("COLUMNS")
+	os.Exit(m.Run())
+}
+
+func TestHelpWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  string
+		expected int
+	}{
+		{name: "Columns", columns: "120", expected: 120},
+		{name: "Unset", columns: "", expected: defaultHelpWidth},
+		{name: "Invalid", columns: "wide", expected: defaultHelpWidth},
+		{name: "Negative", columns: "-40", expected: defaultHelpWidth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("COLUMNS", tt.columns)
+			assert.Equal(t, tt.expected, helpWidth(&bytes.Buffer{}))
+		})
+	}
+}
+
+func TestHelpWidthFromColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "40")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd())
+	root.SetArgs([]string{"next", "--help"})
+
+	err := Execute(root, WithStdout(&buf))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_width_40.golden")
+}
+
+func TestHelpWidthOverridesColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "40")
+
+	var buf bytes.Buffer
+
+	root := newRootCmd()
+	root.AddCommand(newNextCmd())
+	root.SetArgs([]string{"next", "--help"})
+
+	err := Execute(root, WithStdout(&buf), WithHelpWidth(120))
+	require.NoError(t, err)
+
+	golden.Assert(t, buf.String(), "help_width_120.golden")
+}
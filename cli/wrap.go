@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"math"
 	"strings"
 
 	"github.com/muesli/reflow/wordwrap"
@@ -10,7 +11,41 @@ func wrapText(s string, width int) string {
 	if width <= 0 {
 		return s
 	}
-	return wordwrap.String(unfill(s), width)
+
+	lines := strings.Split(unfill(s), "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrapLine wraps a single line to width. A list item is wrapped with a
+// hanging indent, aligning its continuation lines with the text after the
+// list marker.
+func wrapLine(line string, width int) string {
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	hanging := indent + listMarker(line[indent:])
+	if hanging == indent || hanging >= width {
+		return wordWrap(line, width)
+	}
+
+	wrapped := strings.Split(wordWrap(line[hanging:], width-hanging), "\n")
+	wrapped[0] = line[:hanging] + wrapped[0]
+	for i := 1; i < len(wrapped); i++ {
+		wrapped[i] = strings.Repeat(" ", hanging) + wrapped[i]
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+// wordWrap wraps s to width, breaking lines at spaces only. By default, reflow
+// also breaks words at a hyphen without counting it towards the width of the
+// line, splitting flags such as --format and overflowing the width.
+func wordWrap(s string, width int) string {
+	w := wordwrap.NewWriter(width)
+	w.Breakpoints = nil
+	_, _ = w.Write([]byte(s))
+	_ = w.Close()
+	return w.String()
 }
 
 // Inspiration taken from https://github.com/mgeisler/textwrap/blob/master/src/refill.rs
@@ -20,15 +55,16 @@ func unfill(s string) string {
 	hasTrailingNewline := strings.HasSuffix(s, "\n")
 	s = strings.TrimSuffix(s, "\n")
 
-	paragraphs := strings.Split(s, "\n\n")
-	for i, para := range paragraphs {
-		para = strings.ReplaceAll(para, "\n", " ")
-
-		for strings.Contains(para, "  ") {
-			para = strings.ReplaceAll(para, "  ", " ")
+	base := math.MaxInt
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			base = min(base, indentWidth(line))
 		}
+	}
 
-		paragraphs[i] = strings.TrimSpace(para)
+	paragraphs := strings.Split(s, "\n\n")
+	for i, para := range paragraphs {
+		paragraphs[i] = unfillParagraph(para, base)
 	}
 
 	s = strings.Join(paragraphs, "\n\n")
@@ -37,3 +73,60 @@ func unfill(s string) string {
 	}
 	return s
 }
+
+// unfillParagraph joins the lines of a paragraph into a single line. Lines
+// that start a list item, or that are indented beyond the base indentation
+// of the text, are kept on their own line. An indented line is kept verbatim
+// unless it continues the text of the list item above it.
+func unfillParagraph(para string, base int) string {
+	var out []string
+	verbatim := false
+	hanging := -1
+	for _, line := range strings.Split(para, "\n") {
+		text := collapseSpaces(strings.TrimSpace(line))
+		if text == "" {
+			continue
+		}
+		indent := indentWidth(line) - base
+
+		switch {
+		case listMarker(text) > 0:
+			out = append(out, strings.Repeat(" ", indent)+text)
+			hanging = indent + listMarker(text)
+			verbatim = false
+		case indent > 0 && indent != hanging:
+			out = append(out, strings.TrimRight(trimIndent(line, base), " \t"))
+			hanging = -1
+			verbatim = true
+		case len(out) == 0 || verbatim:
+			out = append(out, text)
+			verbatim = false
+		default:
+			out[len(out)-1] += " " + text
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// listMarker returns the width of the list marker that text starts with,
+// including the space that follows it, or 0 if text is not a list item.
+// Both bullets (- or *) and numbered items (1.) are recognised.
+func listMarker(text string) int {
+	if strings.HasPrefix(text, "- ") || strings.HasPrefix(text, "* ") {
+		return 2
+	}
+
+	digits := len(text) - len(strings.TrimLeft(text, "0123456789"))
+	if digits > 0 && strings.HasPrefix(text[digits:], ". ") {
+		return digits + 2
+	}
+	return 0
+}
+
+func collapseSpaces(s string) string {
+	for strings.Contains(s, "  ") {
+		s = strings.ReplaceAll(s, "  ", " ")
+	}
+	return s
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,6 +68,31 @@ func TestUnfill(t *testing.T) {
 			in:   "first\n\nsecond\n",
 			want: "first\n\nsecond\n",
 		},
+		{
+			name: "BulletedList",
+			in:   "supported formats:\n- json\n* yaml with\n  anchors\n- toml",
+			want: "supported formats:\n- json\n* yaml with anchors\n- toml",
+		},
+		{
+			name: "NumberedList",
+			in:   "steps:\n1. build the\nbinary\n10. release it",
+			want: "steps:\n1. build the binary\n10. release it",
+		},
+		{
+			name: "NestedList",
+			in:   "- first\n  - nested\n- second",
+			want: "- first\n  - nested\n- second",
+		},
+		{
+			name: "IndentedCodeBlock",
+			in:   "for example:\n    app run  --flag\n    app stop\nto finish",
+			want: "for example:\n    app run  --flag\n    app stop\nto finish",
+		},
+		{
+			name: "HyphenatedWord",
+			in:   "a long\n-lived token",
+			want: "a long -lived token",
+		},
 	}
 
 	for _, tt := range tests {
@@ -76,3 +102,32 @@ func TestUnfill(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapTextPreservesLists(t *testing.T) {
+	in := `Deploys the application:
+- builds every binary for each of the supported platforms
+- publishes the release
+
+    app deploy --dry-run`
+
+	want := `Deploys the application:
+- builds every binary for
+  each of the supported
+  platforms
+- publishes the release
+
+    app deploy --dry-run`
+
+	assert.Equal(t, want, wrapText(in, 25))
+}
+
+func TestWrapTextKeepsHyphenatedWords(t *testing.T) {
+	in := "Use --format to change the output, or --dry-run to preview"
+	want := "Use --format to change\nthe output, or\n--dry-run to preview"
+
+	got := wrapText(in, 22)
+	assert.Equal(t, want, got)
+	for line := range strings.SplitSeq(got, "\n") {
+		assert.LessOrEqual(t, len(line), 22)
+	}
+}
@@ -0,0 +1,87 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// Shade scales ordered from lightest to darkest, indexed by the
+// Adaptive helpers.
+var (
+	purpleShades = []lipgloss.Color{Purple50, Purple100, Purple200, Purple300, Purple400, Purple500, Purple600, Purple700, Purple800, Purple900}
+	greenShades  = []lipgloss.Color{Green50, Green100, Green200, Green300, Green400, Green500, Green600, Green700, Green800, Green900}
+	orangeShades = []lipgloss.Color{Orange50, Orange100, Orange200, Orange300, Orange400, Orange500, Orange600, Orange700, Orange800, Orange900}
+	redShades    = []lipgloss.Color{Red50, Red100, Red200, Red300, Red400, Red500, Red600, Red700, Red800, Red900}
+	blueShades   = []lipgloss.Color{Blue50, Blue100, Blue200, Blue300, Blue400, Blue500, Blue600, Blue700, Blue800, Blue900}
+	grayShades   = []lipgloss.Color{Gray50, Gray100, Gray200, Gray300, Gray400, Gray500, Gray600, Gray700, Gray800, Gray900}
+)
+
+// AdaptivePurple returns an adaptive color using the purple shade at index
+// light for light terminals and index dark for dark terminals. Indices range
+// from 0 (Purple50) to 9 (Purple900) and are clamped to that range.
+//
+//	// Equivalent to {Light: Purple400, Dark: Purple50}
+//	theme.AdaptivePurple(4, 0)
+func AdaptivePurple(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(purpleShades, light, dark)
+}
+
+// AdaptiveGreen returns an adaptive color using the green shades at the
+// given indices. See [AdaptivePurple] for details.
+func AdaptiveGreen(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(greenShades, light, dark)
+}
+
+// AdaptiveOrange returns an adaptive color using the orange shades at the
+// given indices. See [AdaptivePurple] for details.
+func AdaptiveOrange(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(orangeShades, light, dark)
+}
+
+// AdaptiveRed returns an adaptive color using the red shades at the given
+// indices. See [AdaptivePurple] for details.
+func AdaptiveRed(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(redShades, light, dark)
+}
+
+// AdaptiveBlue returns an adaptive color using the blue shades at the given
+// indices. See [AdaptivePurple] for details.
+func AdaptiveBlue(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(blueShades, light, dark)
+}
+
+// AdaptiveGray returns an adaptive color using the gray shades at the given
+// indices. See [AdaptivePurple] for details.
+func AdaptiveGray(light, dark int) lipgloss.AdaptiveColor {
+	return adaptive(grayShades, light, dark)
+}
+
+// Default adaptive colors for each hue, readable on both light and dark
+// terminals.
+var (
+	// PurpleAdaptive is the default adaptive purple.
+	PurpleAdaptive = AdaptivePurple(4, 0)
+
+	// GreenAdaptive is the default adaptive green.
+	GreenAdaptive = AdaptiveGreen(6, 0)
+
+	// OrangeAdaptive is the default adaptive orange.
+	OrangeAdaptive = AdaptiveOrange(5, 0)
+
+	// RedAdaptive is the default adaptive red.
+	RedAdaptive = AdaptiveRed(5, 0)
+
+	// BlueAdaptive is the default adaptive blue.
+	BlueAdaptive = AdaptiveBlue(4, 1)
+
+	// GrayAdaptive is the default adaptive gray.
+	GrayAdaptive = AdaptiveGray(7, 3)
+)
+
+func adaptive(shades []lipgloss.Color, light, dark int) lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{
+		Light: string(shade(shades, light)),
+		Dark:  string(shade(shades, dark)),
+	}
+}
+
+func shade(shades []lipgloss.Color, i int) lipgloss.Color {
+	return shades[max(0, min(i, len(shades)-1))]
+}
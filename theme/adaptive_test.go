@@ -0,0 +1,49 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		color    func(light, dark int) lipgloss.AdaptiveColor
+		light    int
+		dark     int
+		expected lipgloss.AdaptiveColor
+	}{
+		{name: "Purple", color: AdaptivePurple, light: 4, dark: 0, expected: lipgloss.AdaptiveColor{Light: "#4b30ab", Dark: "#a980db"}},
+		{name: "Green", color: AdaptiveGreen, light: 6, dark: 0, expected: lipgloss.AdaptiveColor{Light: "#20813f", Dark: "#80dba9"}},
+		{name: "Orange", color: AdaptiveOrange, light: 5, dark: 0, expected: lipgloss.AdaptiveColor{Light: "#964e28", Dark: "#dba980"}},
+		{name: "Red", color: AdaptiveRed, light: 5, dark: 0, expected: lipgloss.AdaptiveColor{Light: "#962828", Dark: "#db8080"}},
+		{name: "Blue", color: AdaptiveBlue, light: 6, dark: 3, expected: lipgloss.AdaptiveColor{Light: "#203f81", Dark: "#4470b7"}},
+		{name: "Gray", color: AdaptiveGray, light: 7, dark: 3, expected: lipgloss.AdaptiveColor{Light: "#6c6c6c", Dark: "#b7b7b7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.color(tt.light, tt.dark))
+		})
+	}
+}
+
+func TestAdaptiveColorsClampIndices(t *testing.T) {
+	tests := []struct {
+		name     string
+		light    int
+		dark     int
+		expected lipgloss.AdaptiveColor
+	}{
+		{name: "BelowRange", light: -1, dark: -10, expected: lipgloss.AdaptiveColor{Light: "#a980db", Dark: "#a980db"}},
+		{name: "AboveRange", light: 10, dark: 99, expected: lipgloss.AdaptiveColor{Light: "#050842", Dark: "#050842"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AdaptivePurple(tt.light, tt.dark))
+		})
+	}
+}
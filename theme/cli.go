@@ -9,25 +9,25 @@ import (
 // Each color adapts for readability on light and dark terminals.
 var (
 	// CommandText styles command and subcommand names.
-	CommandText = lipgloss.AdaptiveColor{Light: string(Purple400), Dark: string(Purple50)}
+	CommandText = AdaptivePurple(4, 0)
 
 	// CommentText styles comment lines in examples.
-	CommentText = lipgloss.AdaptiveColor{Light: string(Green600), Dark: string(Green50)}
+	CommentText = AdaptiveGreen(6, 0)
 
 	// EnvVarText styles environment variable names in examples.
-	EnvVarText = lipgloss.AdaptiveColor{Light: string(Blue400), Dark: string(Blue100)}
+	EnvVarText = AdaptiveBlue(4, 1)
 
 	// EnvVarValueText styles environment variable values in examples (dimmer than name).
-	EnvVarValueText = lipgloss.AdaptiveColor{Light: string(Blue600), Dark: string(Blue300)}
+	EnvVarValueText = AdaptiveBlue(6, 3)
 
 	// FlagText styles flag names.
-	FlagText = lipgloss.AdaptiveColor{Light: string(Orange500), Dark: string(Orange50)}
+	FlagText = AdaptiveOrange(5, 0)
 
 	// FlagMetaText styles flag metadata such as type hints and default values.
-	FlagMetaText = lipgloss.AdaptiveColor{Light: string(Purple500), Dark: string(Purple100)}
+	FlagMetaText = AdaptivePurple(5, 1)
 
 	// OperatorText styles shell operators in examples.
-	OperatorText = lipgloss.AdaptiveColor{Light: string(Red500), Dark: string(Red50)}
+	OperatorText = AdaptiveRed(5, 0)
 )
 
 // PurpleClayCLI returns the official PurpleClay CLI theme. Colors adapt
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/purpleclay/x/theme"
@@ -14,6 +15,10 @@ func main() {
 		colors(),
 		"",
 		typography(),
+		"",
+		status(),
+		"",
+		tables(),
 	)
 	fmt.Fprint(os.Stdout, lipgloss.NewStyle().Margin(2, 2).Render(out))
 }
@@ -60,6 +65,10 @@ func colors() string {
 		theme.Blue50, theme.Blue100, theme.Blue200, theme.Blue300, theme.Blue400,
 		theme.Blue500, theme.Blue600, theme.Blue700, theme.Blue800, theme.Blue900,
 	}
+	gray := []lipgloss.Color{
+		theme.Gray50, theme.Gray100, theme.Gray200, theme.Gray300, theme.Gray400,
+		theme.Gray500, theme.Gray600, theme.Gray700, theme.Gray800, theme.Gray900,
+	}
 
 	return lipgloss.JoinVertical(
 		lipgloss.Top,
@@ -74,6 +83,8 @@ func colors() string {
 		colorRow(red),
 		"",
 		colorRow(blue),
+		"",
+		colorRow(gray),
 	)
 }
 
@@ -95,6 +106,7 @@ func typography() string {
 		theme.Underline.Render("Underline")+"  ",
 		theme.Strikethrough.Render("Strikethrough")+"  ",
 		theme.Code.Render("Code")+"  ",
+		theme.Muted.Render("Muted")+"  ",
 		theme.Mark.Render("Mark")+"  ",
 		theme.Link.Render("Link"),
 	)
@@ -108,3 +120,44 @@ func typography() string {
 		styles,
 	)
 }
+
+func status() string {
+	progress := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		theme.Spinner.Render("⣾")+"  ",
+		theme.ProgressFilled.Render(strings.Repeat("█", 14)),
+		theme.ProgressEmpty.Render(strings.Repeat("░", 6)),
+	)
+
+	outcomes := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		theme.StatusSuccess.Render("✓ Success")+"  ",
+		theme.StatusWarning.Render("! Warning")+"  ",
+		theme.StatusError.Render("✗ Error"),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		theme.H6.Render("Status"),
+		"",
+		progress,
+		"",
+		outcomes,
+	)
+}
+
+func tables() string {
+	return lipgloss.JoinVertical(
+		lipgloss.Top,
+		theme.H6.Render("Table"),
+		"",
+		theme.Table(
+			[]string{"MODULE", "VERSION"},
+			[][]string{
+				{"github.com/charmbracelet/lipgloss", "v1.1.0"},
+				{"github.com/spf13/cobra", "v1.10.2"},
+				{"github.com/spf13/pflag", "v1.0.10"},
+			},
+		),
+	)
+}
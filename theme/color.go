@@ -77,6 +77,23 @@ var (
 	Blue900 = lipgloss.Color("#081242")
 )
 
+// Gray is a neutral palette for secondary and de-emphasized content, such as
+// hints, timestamps and borders. Being free of any hue, it relies purely on
+// lightness for contrast and remains distinguishable for color-blind users.
+// Shades range from lightest (Gray50) to darkest (Gray900).
+var (
+	Gray50  = lipgloss.Color("#dbdbdb")
+	Gray100 = lipgloss.Color("#cfcfcf")
+	Gray200 = lipgloss.Color("#c3c3c3")
+	Gray300 = lipgloss.Color("#b7b7b7")
+	Gray400 = lipgloss.Color("#ababab")
+	Gray500 = lipgloss.Color("#969696")
+	Gray600 = lipgloss.Color("#818181")
+	Gray700 = lipgloss.Color("#6c6c6c")
+	Gray800 = lipgloss.Color("#575757")
+	Gray900 = lipgloss.Color("#424242")
+)
+
 // ANSI standard colors (0-7).
 var (
 	Black   = lipgloss.Color("0")
@@ -0,0 +1,47 @@
+package theme
+
+import "github.com/charmbracelet/lipgloss"
+
+// Progress styles for spinners and progress bars. Each style adapts
+// for light and dark terminals.
+var (
+	// Spinner renders the frames of an activity spinner.
+	Spinner = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+		Light: string(Purple400),
+		Dark:  string(Purple50),
+	})
+
+	// ProgressFilled renders the completed portion of a progress bar.
+	ProgressFilled = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+		Light: string(Purple300),
+		Dark:  string(Purple100),
+	})
+
+	// ProgressEmpty renders the remaining portion of a progress bar.
+	ProgressEmpty = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{
+		Light: string(Gray200),
+		Dark:  string(Gray800),
+	})
+)
+
+// Status styles for reporting the outcome of an operation. Each style
+// adapts for light and dark terminals.
+var (
+	// StatusSuccess renders a successful outcome.
+	StatusSuccess = Bold.Foreground(lipgloss.AdaptiveColor{
+		Light: string(Green600),
+		Dark:  string(Green50),
+	})
+
+	// StatusWarning renders an outcome that needs attention.
+	StatusWarning = Bold.Foreground(lipgloss.AdaptiveColor{
+		Light: string(Orange500),
+		Dark:  string(Orange50),
+	})
+
+	// StatusError renders a failed outcome.
+	StatusError = Bold.Foreground(lipgloss.AdaptiveColor{
+		Light: string(Red500),
+		Dark:  string(Red50),
+	})
+)
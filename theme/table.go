@@ -0,0 +1,78 @@
+package theme
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// Table styles for rendering structured output. Each style adapts for
+// light and dark terminals.
+var (
+	// TableHeader renders the header row of a table.
+	TableHeader = lipgloss.NewStyle().Padding(0, 1).Bold(true).Foreground(lipgloss.Color("#ffffff")).Background(lipgloss.AdaptiveColor{
+		Light: string(Purple400),
+		Dark:  string(Purple600),
+	})
+
+	// TableRow renders the odd rows of a table.
+	TableRow = lipgloss.NewStyle().Padding(0, 1).Background(lipgloss.AdaptiveColor{
+		Light: string(Gray50),
+		Dark:  string(Gray900),
+	})
+
+	// TableRowAlt renders the even rows of a table, alternating with
+	// TableRow.
+	TableRowAlt = lipgloss.NewStyle().Padding(0, 1).Background(lipgloss.AdaptiveColor{
+		Light: string(Gray100),
+		Dark:  string(Gray800),
+	})
+
+	// TableBorder renders the border of a table.
+	TableBorder = lipgloss.NewStyle().Foreground(PurpleAdaptive)
+)
+
+// Table renders headers and rows as a table, using the purple palette for
+// the header row and alternating muted backgrounds for each row. Rows with
+// fewer cells than the widest row are padded with empty cells.
+//
+//	fmt.Println(theme.Table(
+//	    []string{"MODULE", "VERSION"},
+//	    [][]string{
+//	        {"github.com/spf13/cobra", "v1.10.2"},
+//	        {"github.com/charmbracelet/lipgloss", "v1.1.0"},
+//	    },
+//	))
+func Table(headers []string, rows [][]string) string {
+	columns := len(headers)
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+
+	padded := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		padded = append(padded, pad(row, columns))
+	}
+
+	return table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(TableBorder).
+		Headers(pad(headers, columns)...).
+		Rows(padded...).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			switch {
+			case row == table.HeaderRow:
+				return TableHeader
+			case row%2 == 0:
+				return TableRow
+			default:
+				return TableRowAlt
+			}
+		}).
+		Render()
+}
+
+func pad(cells []string, n int) []string {
+	padded := make([]string, n)
+	copy(padded, cells)
+	return padded
+}
@@ -0,0 +1,64 @@
+package theme
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable(t *testing.T) {
+	out := Table(
+		[]string{"MODULE", "VERSION"},
+		[][]string{
+			{"github.com/spf13/cobra", "v1.10.2"},
+			{"github.com/charmbracelet/lipgloss", "v1.1.0"},
+		},
+	)
+
+	for _, cell := range []string{"MODULE", "VERSION", "github.com/spf13/cobra", "v1.10.2", "github.com/charmbracelet/lipgloss", "v1.1.0"} {
+		assert.Contains(t, out, cell)
+	}
+}
+
+func TestTableRaggedRows(t *testing.T) {
+	out := Table(
+		[]string{"NAME"},
+		[][]string{
+			{"nsv", "v0.1.0", "stable"},
+			{"x"},
+		},
+	)
+
+	lines := strings.Split(out, "\n")
+	require.NotEmpty(t, lines)
+
+	// Every line spans the width of the widest row
+	width := lipgloss.Width(lines[0])
+	for _, line := range lines {
+		assert.Equal(t, width, lipgloss.Width(line))
+	}
+	for _, cell := range []string{"NAME", "nsv", "v0.1.0", "stable", "x"} {
+		assert.Contains(t, out, cell)
+	}
+}
+
+func TestTableHeaderStyle(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+
+	out := Table([]string{"NAME"}, [][]string{{"nsv"}})
+
+	lines := strings.Split(out, "\n")
+	require.Greater(t, len(lines), 2)
+
+	// The header row follows the top border
+	header := TableHeader.Render("NAME")
+	prefix := header[:strings.Index(header, "NAME")]
+	assert.Contains(t, lines[1], strings.TrimSuffix(prefix, " "))
+	assert.NotContains(t, lines[3], strings.TrimSuffix(prefix, " "))
+}
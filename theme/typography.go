@@ -67,6 +67,14 @@ var (
 			Dark:  string(Purple50),
 		})
 
+	// Muted renders secondary text that should recede behind the main
+	// content, such as hints or metadata.
+	Muted = lipgloss.NewStyle().
+		Foreground(lipgloss.AdaptiveColor{
+			Light: string(Gray700),
+			Dark:  string(Gray300),
+		})
+
 	// Mark renders highlighted text with a background color.
 	Mark = lipgloss.NewStyle().
 		Padding(0, 1).